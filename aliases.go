@@ -0,0 +1,96 @@
+package govalidator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AliasMap maps the names of aliases to the tags they stand for, e.g.
+// "password": "required,printableascii,minstringlength(12),maxstringlength(128)".
+// An alias is used like a validator, `valid:"password"`, and may reference other aliases.
+// Errors of the rules of an alias report the alias name as Error.Validator.
+var AliasMap = map[string]string{}
+
+// RegisterAlias adds an alias to the package level validators, see AliasMap.
+// It fails if tag is malformed or if the alias would reference itself, directly or through other aliases.
+func RegisterAlias(name, tag string) error {
+	return defaultValidate.RegisterAlias(name, tag)
+}
+
+// RegisterAlias adds an alias usable as `valid:"name"`, which expands into the rules of tag.
+// It fails if tag is malformed, see ParseTag, or if the alias would reference itself, directly or
+// through other aliases.
+func (vd *Validate) RegisterAlias(name, tag string) error {
+	if _, err := ParseTag(tag); err != nil {
+		return err
+	}
+	vd.mu.Lock()
+	defer vd.mu.Unlock()
+	if err := aliasCycle(vd.aliasMap, tag, []string{name}); err != nil {
+		return err
+	}
+	vd.aliasMap[name] = tag
+	vd.resetPlans()
+	return nil
+}
+
+// aliasCycle checks that the rules of tag, which is (about to be) the tag of the alias path[0],
+// do not lead back to an alias in path.
+func aliasCycle(aliases map[string]string, tag string, path []string) error {
+	rules, _ := ParseTag(tag)
+	for _, r := range rules {
+		spec := r.Rule
+		for _, seen := range path {
+			if spec == seen {
+				return fmt.Errorf("alias %s references itself: %s", path[0], strings.Join(append(path, spec), " -> "))
+			}
+		}
+		if body, ok := aliases[spec]; ok {
+			if err := aliasCycle(aliases, body, append(path, spec)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (vd *Validate) lookupAlias(name string) (string, bool) {
+	vd.mu.RLock()
+	defer vd.mu.RUnlock()
+	tag, ok := vd.aliasMap[name]
+	return tag, ok
+}
+
+// expandAlias resolves the rules of the tag of an alias which apply to the active groups, following the
+// aliases it references. message is the custom error message of the alias, used by rules without their own.
+// ok is false if the alias references itself, which can only happen if AliasMap was modified directly.
+func (vd *Validate) expandAlias(tag, message, groups string, path []string) (rules []*rule, ok bool) {
+	// the tag was checked by RegisterAlias, unless AliasMap was modified directly
+	parsed, _ := ParseTag(tag)
+	for _, tr := range parsed {
+		if !groupsActive(tr.Groups, groups) {
+			continue
+		}
+		spec := tr.Rule
+		if tr.Message == "" {
+			tr.Message = message
+		}
+		msg := tr.Message
+		body, isAlias := vd.lookupAlias(spec)
+		if !isAlias {
+			rules = append(rules, vd.resolveRule(tr))
+			continue
+		}
+		for _, seen := range path {
+			if seen == spec {
+				return nil, false
+			}
+		}
+		sub, ok := vd.expandAlias(body, msg, groups, append(path, spec))
+		if !ok {
+			return nil, false
+		}
+		rules = append(rules, sub...)
+	}
+	return rules, true
+}
@@ -0,0 +1,87 @@
+package govalidator
+
+import (
+	"testing"
+)
+
+func TestAliases(t *testing.T) {
+	t.Parallel()
+
+	vd := New()
+	if err := vd.RegisterAlias("password", "required,printableascii,minstringlength(12),maxstringlength(128)"); err != nil {
+		t.Fatal(err)
+	}
+	if err := vd.RegisterAlias("secret", "password,!matches(1234)~no sequences"); err != nil {
+		t.Fatal(err)
+	}
+
+	type account struct {
+		Password string `valid:"secret"`
+		PIN      string `valid:"password~pin too short"`
+	}
+
+	var tests = []struct {
+		name      string
+		param     account
+		expected  string
+		validator string
+	}{
+		{"valid", account{"correct horse battery", "staple staple"}, "", ""},
+		{"too short", account{"short", "staple staple"}, "Password: short does not validate as minstringlength(12)", "secret"},
+		{"missing", account{"", "staple staple"}, "Password: non zero value required", "secret"},
+		{"nested custom message", account{"correct horse 1234", "staple staple"}, "no sequences", "secret"},
+		{"message of the alias", account{"correct horse battery", "staple"}, "pin too short", "password"},
+	}
+	for _, test := range tests {
+		ok, err := vd.ValidateStruct(test.param)
+		if ok != (test.expected == "") {
+			t.Errorf("%s: expected %t, got %t: %v", test.name, test.expected == "", ok, err)
+			continue
+		}
+		if test.expected == "" {
+			continue
+		}
+		errs := flattenErrors(err)
+		if len(errs) != 1 || errs[0].Error() != test.expected || errs[0].(Error).Validator != test.validator {
+			t.Errorf("%s: expected %q reported by %s, got %#v", test.name, test.expected, test.validator, errs)
+		}
+	}
+
+	template := map[string]interface{}{"password": "secret"}
+	if ok, _ := vd.ValidateMap(map[string]interface{}{"password": "short"}, template); ok {
+		t.Error("expected alias in a validation map to be applied")
+	}
+	ok, err := vd.ValidateMap(map[string]interface{}{}, template)
+	if ok || err == nil || flattenErrors(err)[0].(Error).Validator != "secret" {
+		t.Errorf("expected missing key to be reported by the alias, got %v", err)
+	}
+}
+
+func TestAliasCycles(t *testing.T) {
+	t.Parallel()
+
+	vd := New()
+	if err := vd.RegisterAlias("a", "b,alpha"); err != nil {
+		t.Fatal(err)
+	}
+	if err := vd.RegisterAlias("b", "c"); err != nil {
+		t.Fatal(err)
+	}
+	err := vd.RegisterAlias("c", "required,a")
+	if err == nil || err.Error() != "alias c references itself: c -> a -> b -> c" {
+		t.Errorf("expected cycle to be detected, got %v", err)
+	}
+	if err := vd.RegisterAlias("self", "self@create"); err == nil {
+		t.Error("expected alias referencing itself to be rejected")
+	}
+
+	// cycles introduced by modifying the map directly are reported as invalid validators
+	vd.aliasMap["c"] = "a"
+	vd.resetPlans()
+	type cyclic struct {
+		Name string `valid:"a"`
+	}
+	if ok, err := vd.ValidateStruct(cyclic{"x"}); ok || err == nil {
+		t.Error("expected cyclic alias to be reported")
+	}
+}
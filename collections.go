@@ -0,0 +1,227 @@
+package govalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// collectionTagMap maps the tags constraining a slice, array or map as a whole to their implementation.
+// Unlike other validators they are applied to empty collections as well.
+var collectionTagMap = map[string]func(v reflect.Value, n int) bool{
+	"minitems": func(v reflect.Value, n int) bool { return v.Len() >= n },
+	"maxitems": func(v reflect.Value, n int) bool { return v.Len() <= n },
+	"nonempty": func(v reflect.Value, n int) bool { return v.Len() > 0 },
+	"unique":   func(v reflect.Value, n int) bool { return isUnique(v) },
+}
+
+// collectionTagParams tells whether a collection tag expects a parameter.
+var collectionTagParams = map[string]bool{
+	"minitems": true,
+	"maxitems": true,
+}
+
+var rxCollectionTag = regexp.MustCompile(`^(\w+)(?:\((\d+)\))?$`)
+
+const (
+	diveTag    = "dive"
+	keysTag    = "keys"
+	endKeysTag = "endkeys"
+)
+
+// matchCollectionTag checks whether validator is a collection tag like `maxitems(10)`.
+func matchCollectionTag(validator string) (func(reflect.Value, int) bool, []string, int, bool) {
+	ps := rxCollectionTag.FindStringSubmatch(validator)
+	if len(ps) == 0 {
+		return nil, nil, 0, false
+	}
+	fn, ok := collectionTagMap[ps[1]]
+	if !ok || collectionTagParams[ps[1]] != (ps[2] != "") {
+		return nil, nil, 0, false
+	}
+	if ps[2] == "" {
+		return fn, nil, 0, true
+	}
+	n, err := strconv.Atoi(ps[2])
+	return fn, []string{ps[2]}, n, err == nil
+}
+
+func isCollectionKind(k reflect.Kind) bool {
+	return k == reflect.Slice || k == reflect.Array || k == reflect.Map
+}
+
+// isUnique checks that the elements of a slice or array, or the values of a map, are distinct.
+func isUnique(v reflect.Value) bool {
+	var values []reflect.Value
+	if v.Kind() == reflect.Map {
+		iter := v.MapRange()
+		for iter.Next() {
+			values = append(values, iter.Value())
+		}
+	} else {
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, v.Index(i))
+		}
+	}
+	seen := make(map[interface{}]struct{}, len(values))
+	var others []interface{}
+	for _, e := range values {
+		value := e.Interface()
+		if !reflect.ValueOf(value).Comparable() {
+			// e.g. slices held by an interface{}, which cannot be map keys
+			for _, other := range others {
+				if reflect.DeepEqual(value, other) {
+					return false
+				}
+			}
+			others = append(others, value)
+			continue
+		}
+		if _, ok := seen[value]; ok {
+			return false
+		}
+		seen[value] = struct{}{}
+	}
+	return true
+}
+
+// splitDive splits a tag at its first dive marker into the rules for the value itself,
+// the rules for map keys (between keys and endkeys) and the rules for each element.
+func splitDive(tag string) (own, keys, elem string, hasDive bool) {
+	parsed, err := splitOptions(tag)
+	if err != nil {
+		// the whole tag is parsed again and reported as malformed
+		return tag, "", "", false
+	}
+	options := make([]string, len(parsed))
+	for i, o := range parsed {
+		options[i] = o.text
+	}
+	for i, option := range options {
+		if option != diveTag {
+			continue
+		}
+		rest := options[i+1:]
+		if len(rest) > 0 && rest[0] == keysTag {
+			for j := 1; j < len(rest); j++ {
+				if rest[j] == endKeysTag {
+					keys = strings.Join(rest[1:j], ",")
+					rest = rest[j+1:]
+					break
+				}
+			}
+		}
+		return strings.Join(options[:i], ","), keys, strings.Join(rest, ","), true
+	}
+	return tag, "", "", false
+}
+
+// checkCollection applies the collection rules of f which were not applied yet to the slice, array or map v.
+// Failures are handed to report, validation stops at the first error report returns.
+func checkCollection(v reflect.Value, f *fieldPlan, applied []bool, report func(error) error) error {
+	for i, r := range f.rules {
+		if r.kind != ruleCollection || applied[i] {
+			continue
+		}
+		applied[i] = true
+		if result := r.collectionFn(v, r.collectionParam); result == r.negate {
+			if err := report(ruleError(f, r, v, strconv.Itoa(v.Len())+" items")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// markMapKey flags the errors of a map key checked by the rules between keys and endkeys, see Error.MapKey.
+func markMapKey(err error) error {
+	switch e := err.(type) {
+	case Error:
+		e.MapKey = true
+		return e
+	case Errors:
+		for i, err := range e {
+			e[i] = markMapKey(err)
+		}
+	}
+	return err
+}
+
+// diveCheck validates every element (and map key) of the slice, array or map v with the rules following
+// the dive marker of f. Errors carry the index or key of the element in their path.
+func (vd *Validate) diveCheck(ctx context.Context, v reflect.Value, f *fieldPlan, o reflect.Value) (bool, error) {
+	result := true
+	var errs Errors
+	failFast := vd.errorModeFor(ctx) == FailFast
+	filter := pathFilterFrom(ctx)
+	elemPlan := f.dive
+	if elemPlan == nil {
+		elemPlan = &tagPlan{}
+	}
+	check := func(elem reflect.Value, key string, plan *tagPlan, isKey bool) {
+		if ctx.Err() != nil || (failFast && len(errs) > 0) {
+			return
+		}
+		sub, own, ok := filter.field(key)
+		if !ok {
+			return
+		}
+		ectx := filterContext(ctx, filter, sub)
+		ef := &fieldPlan{name: key, tagPlan: plan}
+		if elem.Kind() == reflect.Interface && !elem.IsNil() {
+			elem = elem.Elem()
+		}
+		if !isKey && (elem.Kind() == reflect.Struct ||
+			(elem.Kind() == reflect.Ptr && elem.Elem().Kind() == reflect.Struct)) && plan.tag != "-" {
+			ok, err := vd.validateStruct(ectx, elem.Interface())
+			if err != nil {
+				errs = append(errs, prependPathToErrors(err, key))
+			}
+			result = result && ok
+		}
+		var ok2 bool
+		var err error
+		switch {
+		case !own && isCollectionKind(indirectValue(elem).Kind()):
+			// only elements of the element were asked for
+			ok2, err = vd.diveCheck(ectx, indirectValue(elem), ef, o)
+		case !own || plan.tag == "":
+			return
+		default:
+			ok2, err = vd.typeCheck(ectx, elem, ef, o, nil)
+		}
+		if err != nil {
+			if isKey {
+				err = markMapKey(err)
+			}
+			errs = append(errs, err)
+		}
+		result = result && ok2
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			check(v.Index(i), strconv.Itoa(i), elemPlan, false)
+		}
+	case reflect.Map:
+		for _, k := range sortedMapKeys(v) {
+			name := fmt.Sprint(k)
+			if f.keys != nil {
+				check(k, name, f.keys, true)
+			}
+			check(v.MapIndex(k), name, elemPlan, false)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	if len(errs) > 0 {
+		return false, prependPathToErrors(errs, f.name)
+	}
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package govalidator
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCollectionTags(t *testing.T) {
+	t.Parallel()
+
+	type list struct {
+		Tags   []string       `valid:"minitems(1),maxitems(3),unique"`
+		Scores map[string]int `valid:"nonempty,unique"`
+		Fixed  [2]int         `valid:"!unique"`
+	}
+
+	var tests = []struct {
+		name      string
+		param     list
+		expected  bool
+		validator string
+	}{
+		{"valid", list{[]string{"a", "b"}, map[string]int{"a": 1, "b": 2}, [2]int{1, 1}}, true, ""},
+		{"too few", list{nil, map[string]int{"a": 1}, [2]int{}}, false, "minitems"},
+		{"too many", list{[]string{"a", "b", "c", "d"}, map[string]int{"a": 1}, [2]int{}}, false, "maxitems"},
+		{"duplicates", list{[]string{"a", "a"}, map[string]int{"a": 1}, [2]int{}}, false, "unique"},
+		{"empty map", list{[]string{"a"}, map[string]int{}, [2]int{}}, false, "nonempty"},
+		{"duplicate map values", list{[]string{"a"}, map[string]int{"a": 1, "b": 1}, [2]int{}}, false, "unique"},
+		{"negated unique", list{[]string{"a"}, map[string]int{"a": 1}, [2]int{1, 2}}, false, "!unique"},
+	}
+	for _, test := range tests {
+		actual, err := ValidateStruct(test.param)
+		if actual != test.expected {
+			t.Errorf("%s: expected %t, got %t: %v", test.name, test.expected, actual, err)
+			continue
+		}
+		if test.validator != "" {
+			errs := err.(Errors).Errors()
+			if len(errs) != 1 || errs[0].(Error).Validator != test.validator {
+				t.Errorf("%s: expected a single %s error, got %v", test.name, test.validator, err)
+			}
+		}
+	}
+}
+
+func TestUniqueUncomparableValues(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		param    interface{}
+		expected bool
+	}{
+		{"distinct", []interface{}{[]string{"a"}, 1, []string{"b"}, nil}, true},
+		{"duplicate slices", []interface{}{[]string{"a"}, 1, []string{"a"}}, false},
+		{"duplicate comparable values", []interface{}{[]string{"a"}, 1, 1}, false},
+		{"distinct maps", map[string]interface{}{"a": map[string]int{"x": 1}, "b": 1}, true},
+		{"duplicate maps", map[string]interface{}{"a": map[string]int{"x": 1}, "b": map[string]int{"x": 1}}, false},
+		{"uncomparable element type", [][]int{{1}, {1}}, false},
+	}
+	for _, test := range tests {
+		if actual := isUnique(reflect.ValueOf(test.param)); actual != test.expected {
+			t.Errorf("%s: expected %t, got %t", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestDive(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Zip string `valid:"numeric,required"`
+	}
+
+	type contact struct {
+		Emails    []string          `valid:"maxitems(3),dive,email"`
+		Matrix    [][]int           `valid:"dive,maxitems(2),dive,range(0|9)"`
+		Labels    map[string]string `valid:"dive,keys,alpha,endkeys,required"`
+		Addresses []address         `valid:"dive"`
+		Optional  []*string         `valid:"dive,alpha"`
+	}
+
+	bad := "4"
+	var tests = []struct {
+		name     string
+		param    contact
+		expected []string
+	}{
+		{"valid", contact{
+			Emails:    []string{"a@example.com", "b@example.com"},
+			Matrix:    [][]int{{1, 2}, {9}},
+			Labels:    map[string]string{"env": "prod"},
+			Addresses: []address{{"12345"}},
+			Optional:  []*string{nil},
+		}, nil},
+		{"invalid email", contact{Emails: []string{"a@example.com", "foo"}}, []string{
+			"Emails.1: foo does not validate as email",
+		}},
+		{"nested dive", contact{Matrix: [][]int{{1, 10}, {1, 2, 3}}}, []string{
+			"Matrix.0.1: 10 does not validate as range(0|9)",
+			"Matrix.1: 3 items does not validate as maxitems(2)",
+		}},
+		{"map keys and values", contact{Labels: map[string]string{"env1": "prod", "zone": ""}}, []string{
+			"Labels.env1: env1 does not validate as alpha",
+			"Labels.zone: non zero value required",
+		}},
+		{"struct elements", contact{Addresses: []address{{"12345"}, {"abc"}}}, []string{
+			"Addresses.1.Zip: abc does not validate as numeric",
+		}},
+		{"pointer elements", contact{Optional: []*string{&bad}}, []string{
+			"Optional.0: 4 does not validate as alpha",
+		}},
+	}
+	for _, test := range tests {
+		actual, err := ValidateStruct(test.param)
+		if actual != (test.expected == nil) {
+			t.Errorf("%s: expected %t, got %t: %v", test.name, test.expected == nil, actual, err)
+			continue
+		}
+		if test.expected == nil {
+			continue
+		}
+		var messages []string
+		for _, e := range flattenErrors(err) {
+			messages = append(messages, e.Error())
+		}
+		if strings.Join(messages, "\n") != strings.Join(test.expected, "\n") {
+			t.Errorf("%s: expected errors\n%s\ngot\n%s", test.name, strings.Join(test.expected, "\n"), strings.Join(messages, "\n"))
+		}
+	}
+}
+
+func TestDiveMapKeyErrors(t *testing.T) {
+	t.Parallel()
+
+	type labels struct {
+		Labels map[string]string `valid:"dive,keys,alpha,endkeys,alpha"`
+	}
+	_, err := ValidateStruct(labels{map[string]string{"env1": "prod2"}})
+	errs := flattenErrors(err)
+	if len(errs) != 2 {
+		t.Fatalf("expected errors of the key and of the value, got %v", err)
+	}
+	key, value := errs[0].(Error), errs[1].(Error)
+	if !key.MapKey || key.Value != "env1" || key.Pointer() != "/Labels/env1" {
+		t.Errorf("expected an error of the key env1 at /Labels/env1, got %+v", key)
+	}
+	if value.MapKey || value.Value != "prod2" || value.Pointer() != "/Labels/env1" {
+		t.Errorf("expected an error of the value prod2 at /Labels/env1, got %+v", value)
+	}
+	data, _ := json.Marshal(key)
+	expected := `{"field":"/Labels/env1","validator":"alpha","code":"alpha","message":"env1 does not validate as alpha","mapKey":true}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
+func TestDiveOnScalar(t *testing.T) {
+	t.Parallel()
+
+	type scalar struct {
+		Name string `valid:"dive,alpha"`
+	}
+	ok, err := ValidateStruct(scalar{"abc"})
+	if ok || err == nil || !strings.Contains(err.Error(), `"dive"`) {
+		t.Errorf("expected dive on a string to be reported as invalid, got %t: %v", ok, err)
+	}
+}
+
+func flattenErrors(err error) []error {
+	if errs, ok := err.(Errors); ok {
+		var flat []error
+		for _, e := range errs {
+			flat = append(flat, flattenErrors(e)...)
+		}
+		return flat
+	}
+	return []error{err}
+}
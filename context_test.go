@@ -0,0 +1,89 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func TestValidateStructCtxPassesContext(t *testing.T) {
+	t.Parallel()
+
+	vd := New()
+	vd.RegisterCustomTypeTagCtx("tenantPrefix", func(ctx context.Context, i interface{}, o interface{}) bool {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		s, _ := i.(string)
+		return tenant != "" && len(s) > len(tenant) && s[:len(tenant)] == tenant
+	})
+
+	type resource struct {
+		ID string `valid:"tenantPrefix"`
+	}
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme-")
+	if ok, err := vd.ValidateStructCtx(ctx, resource{"acme-1"}); !ok || err != nil {
+		t.Errorf("expected resource of the tenant to be valid, got %v", err)
+	}
+	if ok, err := vd.ValidateStructCtx(ctx, resource{"other-1"}); ok || err == nil {
+		t.Error("expected resource of another tenant to be invalid")
+	}
+	if ok, _ := vd.ValidateStruct(resource{"acme-1"}); ok {
+		t.Error("expected validation without a tenant to fail")
+	}
+
+	ok, err := vd.ValidateMapCtx(ctx, map[string]interface{}{"id": "acme-2"}, map[string]interface{}{"id": "tenantPrefix"})
+	if !ok || err != nil {
+		t.Errorf("expected map of the tenant to be valid, got %v", err)
+	}
+}
+
+func TestValidateStructCtxCancellation(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		Name string `valid:"cancelling"`
+	}
+	type outer struct {
+		Items []inner `valid:"dive"`
+		Email string  `valid:"email"`
+	}
+
+	calls := 0
+	vd := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	vd.RegisterCustomTypeTagCtx("cancelling", func(ctx context.Context, i interface{}, o interface{}) bool {
+		calls++
+		cancel()
+		return true
+	})
+
+	ok, err := vd.ValidateStructCtx(ctx, outer{Items: []inner{{"a"}, {"b"}, {"c"}}, Email: "invalid"})
+	if ok || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %t: %v", ok, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected validation to stop after the first element, got %d calls", calls)
+	}
+
+	ok, err = ValidateMapCtx(ctx, map[string]interface{}{"name": "x"}, map[string]interface{}{"name": "alpha"})
+	if ok || err != context.Canceled {
+		t.Errorf("expected context.Canceled for an already cancelled context, got %t: %v", ok, err)
+	}
+}
+
+func TestSetCtxReplacesSet(t *testing.T) {
+	t.Parallel()
+
+	tm := &customTypeTagMap[any]{validators: make(map[string]CustomTypeValidator[any])}
+	tm.Set("v", func(i, o any) bool { return false })
+	tm.SetCtx("v", func(ctx context.Context, i, o any) bool { return true })
+	if fn, ok := tm.GetCtx("v"); !ok || !fn(context.Background(), nil, nil) {
+		t.Error("expected the context-aware validator to replace the plain one")
+	}
+	tm.Set("v", func(i, o any) bool { return false })
+	if fn, ok := tm.GetCtx("v"); !ok || fn(context.Background(), nil, nil) {
+		t.Error("expected the plain validator to replace the context-aware one")
+	}
+}
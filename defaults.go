@@ -0,0 +1,100 @@
+package govalidator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rxDefaultTag matches the default option of a tag, e.g. `default(8080)`.
+var rxDefaultTag = regexp.MustCompile(`^default\((.*)\)$`)
+
+// applyDefault sets the zero value v to the default of f, if v can be set.
+// Values of fields of a struct passed by value cannot be set and are left alone.
+func applyDefault(v reflect.Value, f *fieldPlan) error {
+	if f.defaults == nil || !v.CanSet() || !isEmptyValue(v) {
+		return nil
+	}
+	if err := setDefault(v, f.defaults.params[0]); err != nil {
+		return f.defaults.newError(f.name, v, err, false)
+	}
+	return nil
+}
+
+// setDefault parses param according to the type of v and stores it in v. The elements of slices are separated by |.
+func setDefault(v reflect.Value, param string) error {
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(param)
+		if err != nil {
+			return fmt.Errorf("invalid default %q for %s", param, v.Type())
+		}
+		v.SetInt(int64(d))
+		return nil
+	case v.Type() == timeType:
+		t, err := time.Parse(time.RFC3339, param)
+		if err != nil {
+			return fmt.Errorf("invalid default %q for %s", param, v.Type())
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(param)
+	case reflect.Bool:
+		b, err := ToBoolean(param)
+		if err != nil {
+			return fmt.Errorf("invalid default %q for %s", param, v.Type())
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(param, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid default %q for %s", param, v.Type())
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(param, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid default %q for %s", param, v.Type())
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(param, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid default %q for %s", param, v.Type())
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		var items []string
+		if param != "" {
+			items = strings.Split(param, "|")
+		}
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setDefault(s.Index(i), item); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Struct:
+		// default() allocates a pointer to a struct, whose fields get their own defaults
+		if param != "" {
+			return fmt.Errorf("default values are not supported for %s", v.Type())
+		}
+	case reflect.Ptr:
+		e := reflect.New(v.Type().Elem())
+		if err := setDefault(e.Elem(), param); err != nil {
+			return err
+		}
+		v.Set(e)
+	default:
+		return fmt.Errorf("default values are not supported for %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package govalidator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultTag(t *testing.T) {
+	t.Parallel()
+
+	type limits struct {
+		Burst uint8 `valid:"default(10)"`
+	}
+	type config struct {
+		Host     string        `valid:"default(localhost),host"`
+		Port     int           `valid:"default(8080),range(1|65535)"`
+		Ratio    float64       `valid:"default(0.5)"`
+		Debug    bool          `valid:"default(true)"`
+		Timeout  time.Duration `valid:"default(30s),maxdur(1m)"`
+		Tags     []string      `valid:"default(a|b),minitems(2)"`
+		Ports    []int         `valid:"default(80|443)"`
+		Retries  *int          `valid:"default(3)"`
+		Limits   *limits       `valid:"default()"`
+		Name     string        `valid:"default(svc),required"`
+		Explicit int           `valid:"default(1)"`
+		Nested   limits
+		Extra    map[string]int `valid:"-"`
+	}
+
+	c := &config{Explicit: 7}
+	ok, err := ValidateStruct(c)
+	if !ok || err != nil {
+		t.Fatalf("expected defaulted config to be valid, got %v", err)
+	}
+	three := 3
+	expected := &config{
+		Host:     "localhost",
+		Port:     8080,
+		Ratio:    0.5,
+		Debug:    true,
+		Timeout:  30 * time.Second,
+		Tags:     []string{"a", "b"},
+		Ports:    []int{80, 443},
+		Retries:  &three,
+		Limits:   &limits{10},
+		Name:     "svc",
+		Explicit: 7,
+		Nested:   limits{10},
+	}
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("expected %+v, got %+v", expected, c)
+	}
+
+	// fields of a struct passed by value cannot be set, their defaults are ignored
+	ok, err = ValidateStruct(config{Name: "x", Tags: []string{"a", "b"}})
+	if !ok || err != nil {
+		t.Errorf("expected struct passed by value to be valid, got %v", err)
+	}
+}
+
+func TestDefaultTagErrors(t *testing.T) {
+	t.Parallel()
+
+	type invalid struct {
+		Small int8          `valid:"default(300)"`
+		Count uint          `valid:"default(-1)"`
+		Flag  bool          `valid:"default(maybe)"`
+		Wait  time.Duration `valid:"default(soon)"`
+		Port  int           `valid:"default(70000),range(1|65535)"`
+		Huge  int64         `valid:"default(9223372036854775808)"`
+		Max   int64         `valid:"default(18446744073709551615)"`
+		Empty int           `valid:"default()"`
+		Size  uint64        `valid:"default(18446744073709551616)"`
+		Ratio float32       `valid:"default(1e39)"`
+	}
+	_, err := ValidateStruct(&invalid{})
+	var messages []string
+	for _, e := range flattenErrors(err) {
+		messages = append(messages, e.Error())
+	}
+	expected := []string{
+		`Small: invalid default "300" for int8`,
+		`Count: invalid default "-1" for uint`,
+		`Flag: invalid default "maybe" for bool`,
+		`Wait: invalid default "soon" for time.Duration`,
+		`Port: 70000 does not validate as range(1|65535)`,
+		`Huge: invalid default "9223372036854775808" for int64`,
+		`Max: invalid default "18446744073709551615" for int64`,
+		`Empty: invalid default "" for int`,
+		`Size: invalid default "18446744073709551616" for uint64`,
+		`Ratio: invalid default "1e39" for float32`,
+	}
+	if strings.Join(messages, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected errors\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(messages, "\n"))
+	}
+}
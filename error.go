@@ -1,7 +1,9 @@
 package govalidator
 
 import (
-	"sort"
+	"encoding/json"
+	"errors"
+	"reflect"
 	"strings"
 )
 
@@ -13,12 +15,25 @@ func (es Errors) Errors() []error {
 	return es
 }
 
+// Unwrap returns the errors held by es, so that errors.Is and errors.As look into every one of them.
+func (es Errors) Unwrap() []error {
+	return es
+}
+
+// appendErrors appends err to es, spreading the errors of err if it is an Errors itself.
+func appendErrors(es Errors, err error) Errors {
+	if errs, ok := err.(Errors); ok {
+		return append(es, errs...)
+	}
+	return append(es, err)
+}
+
+// Error joins the messages of the errors with ";", in the order they were reported.
 func (es Errors) Error() string {
 	var errs []string
 	for _, e := range es {
 		errs = append(errs, e.Error())
 	}
-	sort.Strings(errs)
 	return strings.Join(errs, ";")
 }
 
@@ -31,17 +46,227 @@ type Error struct {
 	// Validator indicates the name of the validator that failed
 	Validator string
 	Path      []string
+
+	// Param holds the parameters of the failed rule, e.g. ["3", "10"] for `stringlength(3|10)`
+	Param []string
+	// Value is the offending value, nil for missing map keys
+	Value any
+	// Kind is the kind of Value, reflect.Invalid for missing map keys
+	Kind reflect.Kind
+	// Code identifies the failure for machine consumption, see the Code constants
+	Code string
+	// MapKey tells that the map key at the end of the path failed the rules between keys and endkeys,
+	// rather than the value stored under it
+	MapKey bool
+}
+
+// Unwrap returns the error reported by the validator, e.g. an error returned by a Validate method.
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Sentinel errors matched by errors.Is for errors of the corresponding codes, e.g.
+// errors.Is(err, govalidator.ErrRequired) tells whether a required value is missing.
+var (
+	// ErrRequired matches errors of missing values, reported by required and the required_* rules
+	ErrRequired = errors.New("value required")
+	// ErrUnsupportedType matches errors of validators which do not support the type of the value,
+	// and UnsupportedTypeError
+	ErrUnsupportedType = errors.New("unsupported type")
+	// ErrInvalidTag matches errors of malformed tags
+	ErrInvalidTag = errors.New("invalid tag")
+)
+
+// codeSentinels maps codes to the sentinel errors matching them.
+var codeSentinels = map[string]error{
+	CodeRequired:        ErrRequired,
+	"required_if":       ErrRequired,
+	"required_unless":   ErrRequired,
+	"required_with":     ErrRequired,
+	"required_with_all": ErrRequired,
+	"required_without":  ErrRequired,
+	CodeUnsupportedKind: ErrUnsupportedType,
+	CodeInvalidTag:      ErrInvalidTag,
 }
 
+// Is reports whether target is the sentinel error of the code of e.
+func (e Error) Is(target error) bool {
+	sentinel, ok := codeSentinels[e.Code]
+	return ok && sentinel == target
+}
+
+// Flatten returns every Error held by err, an Error or Errors as returned by ValidateStruct or ValidateMap,
+// with nested Errors spread and the full path of every field in Path. Errors other than Error, e.g. the
+// error of a cancelled context, are returned as an Error with only Err set.
+func Flatten(err error) []Error {
+	flat := flattenInto(nil, err)
+	errs := make([]Error, 0, len(flat))
+	for _, err := range flat {
+		switch e := err.(type) {
+		case Error:
+			errs = append(errs, e)
+		case *Error:
+			errs = append(errs, *e)
+		default:
+			errs = append(errs, Error{Err: err, Path: []string{}})
+		}
+	}
+	return errs
+}
+
+// ErrorsByPath returns the errors held by err, an Error or Errors as returned by ValidateStruct or ValidateMap,
+// keyed by the dotted path of their field, e.g. "Addresses.2.Zip", see Error.FieldPath. Unlike ErrorsByField,
+// fields of the same name in different nested structs are kept apart and every error of a field is kept,
+// in the order they were reported. Errors other than Error are keyed by "".
+func ErrorsByPath(err error) map[string][]Error {
+	m := make(map[string][]Error)
+	for _, e := range Flatten(err) {
+		path := e.FieldPath()
+		m[path] = append(m[path], e)
+	}
+	return m
+}
+
+// ErrorsAt returns the errors held by err of the field at the dotted path and of the fields below it,
+// e.g. ErrorsAt(err, "Addresses.2") returns the errors of "Addresses.2" and "Addresses.2.Zip" but not of
+// "Addresses.20.Zip". An empty path returns every error.
+func ErrorsAt(err error, path string) []Error {
+	var errs []Error
+	for _, e := range Flatten(err) {
+		if p := e.FieldPath(); path == "" || p == path || strings.HasPrefix(p, path+".") {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+// Codes reported in Error.Code. The errors of validators report the name of the validator, prefixed with
+// "not_" for negated rules, e.g. "stringlength" or "not_in", and the errors of boolean expressions
+// report "expression".
+const (
+	// CodeRequired reports a missing value
+	CodeRequired = "required"
+	// CodeInvalidTag reports a malformed tag, see ParseTag
+	CodeInvalidTag = "invalid_tag"
+	// CodeInvalidValidator reports a rule which is unknown or cannot be applied to the field
+	CodeInvalidValidator = "invalid_validator"
+	// CodeUnsupportedKind reports a validator which does not support the kind of the value
+	CodeUnsupportedKind = "unsupported_kind"
+	// CodeValidate reports an error returned by the Validate method of a Validatable
+	CodeValidate = "validate"
+	// CodeExpression reports a failed boolean expression like `(uuid|ulid)`
+	CodeExpression = "expression"
+	// CodeSanitize reports a sanitize tag which cannot be applied, e.g. with an unknown sanitizer
+	CodeSanitize = "sanitize"
+)
+
 func (e Error) Error() string {
-	if e.CustomErrorMessageExists {
+	if _, ok := e.Err.(*customMessage); e.CustomErrorMessageExists || ok {
 		return e.Err.Error()
 	}
 
 	errName := e.Name
 	if len(e.Path) > 0 {
-		errName = strings.Join(append(e.Path, e.Name), ".")
+		// errors of a struct as a whole, e.g. returned by its Validate method, have no name
+		names := e.Path
+		if e.Name != "" {
+			names = append(names[:len(names):len(names)], e.Name)
+		}
+		errName = strings.Join(names, ".")
+	}
+	if errName == "" {
+		return e.Err.Error()
 	}
 
 	return errName + ": " + e.Err.Error()
 }
+
+// flattenInto appends the errors held by err to es, spreading nested Errors.
+func flattenInto(es []error, err error) []error {
+	if err == nil {
+		return es
+	}
+	if errs, ok := err.(Errors); ok {
+		for _, e := range errs {
+			es = flattenInto(es, e)
+		}
+		return es
+	}
+	return append(es, err)
+}
+
+// Pointer returns the path of the field as a JSON Pointer (RFC 6901), e.g. "/Addresses/2/Zip".
+// It is empty for errors of the validated value as a whole.
+func (e Error) Pointer() string {
+	var b strings.Builder
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	for _, name := range e.segments() {
+		b.WriteByte('/')
+		b.WriteString(escaper.Replace(name))
+	}
+	return b.String()
+}
+
+// FieldPath returns the dotted path of the field, e.g. "Addresses.2.Zip", the key of the Error in ErrorsByPath.
+// It is empty for errors of the validated value as a whole.
+func (e Error) FieldPath() string {
+	return strings.Join(e.segments(), ".")
+}
+
+// segments returns the segments of the path of the field, the Path followed by the Name.
+func (e Error) segments() []string {
+	if e.Name == "" {
+		return e.Path
+	}
+	return append(e.Path[:len(e.Path):len(e.Path)], e.Name)
+}
+
+// message returns the message of e without the name of the field.
+func (e Error) message() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
+// errorJSON is the JSON representation of an Error.
+type errorJSON struct {
+	Field     string   `json:"field"`
+	Validator string   `json:"validator,omitempty"`
+	Code      string   `json:"code,omitempty"`
+	Message   string   `json:"message"`
+	Params    []string `json:"params,omitempty"`
+	MapKey    bool     `json:"mapKey,omitempty"`
+}
+
+// MarshalJSON encodes e as an object with the path of the field as a JSON Pointer, the validator,
+// the code, the message without the name of the field and the parameters of the failed rule, e.g.
+// {"field":"/Addresses/2/Zip","validator":"numeric","code":"numeric","message":"x does not validate as numeric"}.
+// Errors of map keys have "mapKey" set.
+func (e Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorJSON{e.Pointer(), e.Validator, e.Code, e.message(), e.Param, e.MapKey})
+}
+
+// MarshalJSON encodes es as an array of the errors it holds, nested Errors are flattened.
+// Errors other than Error are encoded with their message only.
+func (es Errors) MarshalJSON() ([]byte, error) {
+	flat := flattenInto(nil, es)
+	items := make([]json.RawMessage, 0, len(flat))
+	for _, err := range flat {
+		var item []byte
+		var mErr error
+		switch e := err.(type) {
+		case Error:
+			item, mErr = e.MarshalJSON()
+		case *Error:
+			item, mErr = e.MarshalJSON()
+		default:
+			item, mErr = json.Marshal(errorJSON{Message: err.Error()})
+		}
+		if mErr != nil {
+			return nil, mErr
+		}
+		items = append(items, item)
+	}
+	return json.Marshal(items)
+}
@@ -1,7 +1,10 @@
 package govalidator
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -18,7 +21,7 @@ func TestErrorsToString(t *testing.T) {
 		{Errors{fmt.Errorf("Error 1")}, "Error 1"},
 		{Errors{fmt.Errorf("Error 1"), fmt.Errorf("Error 2")}, "Error 1;Error 2"},
 		{Errors{customErr, fmt.Errorf("Error 2")}, "Custom Error Name: stdlib error;Error 2"},
-		{Errors{fmt.Errorf("Error 123"), customErrWithCustomErrorMessage}, "Bad stuff happened;Error 123"},
+		{Errors{fmt.Errorf("Error 123"), customErrWithCustomErrorMessage}, "Error 123;Bad stuff happened"},
 	}
 	for _, test := range tests {
 		actual := test.param1.Error()
@@ -27,3 +30,201 @@ func TestErrorsToString(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	type product struct {
+		Name     string   `valid:"required"`
+		Title    string   `valid:"stringlength(3|10)"`
+		Color    string   `valid:"in(red|green)"`
+		Code     string   `valid:"!in(x|y)"`
+		Price    int      `valid:"range(1|100)"`
+		Tags     []string `valid:"minitems(2)"`
+		Email    string   `valid:"email|url"`
+		Unknown  string   `valid:"nosuchvalidator"`
+		Category string   `valid:"required,length(1"`
+	}
+	_, err := New(WithErrorMode(AllErrorsPerField)).ValidateStruct(product{
+		Title: "ab", Color: "blue", Code: "x", Price: 200, Tags: []string{"a"}, Email: "none", Unknown: "x",
+	})
+
+	var tests = []struct {
+		name  string
+		code  string
+		param []string
+		value interface{}
+		kind  reflect.Kind
+	}{
+		{"Name", CodeRequired, nil, "", reflect.String},
+		{"Title", "stringlength", []string{"3", "10"}, "ab", reflect.String},
+		{"Color", "in", []string{"red", "green"}, "blue", reflect.String},
+		{"Code", "not_in", []string{"x", "y"}, "x", reflect.String},
+		{"Price", "range", []string{"1", "100"}, 200, reflect.Int},
+		{"Tags", "minitems", []string{"2"}, []string{"a"}, reflect.Slice},
+		{"Email", CodeExpression, nil, "none", reflect.String},
+		{"Unknown", CodeInvalidValidator, nil, "x", reflect.String},
+		{"Category", CodeInvalidTag, nil, nil, reflect.Invalid},
+	}
+	byName := map[string]Error{}
+	for _, e := range Flatten(err) {
+		byName[e.Name] = e
+	}
+	for _, test := range tests {
+		e, ok := byName[test.name]
+		if !ok {
+			t.Errorf("%s: expected an error, got none", test.name)
+			continue
+		}
+		if e.Code != test.code || !reflect.DeepEqual(e.Param, test.param) ||
+			!reflect.DeepEqual(e.Value, test.value) || e.Kind != test.kind {
+			t.Errorf("%s: expected %s %v %v %s, got %s %v %v %s",
+				test.name, test.code, test.param, test.value, test.kind, e.Code, e.Param, e.Value, e.Kind)
+		}
+	}
+
+	_, err = ValidateMap(map[string]interface{}{}, map[string]interface{}{"id": "required,uuid"})
+	if e := Flatten(err)[0]; e.Code != CodeRequired || e.Value != nil || e.Kind != reflect.Invalid {
+		t.Errorf("expected a missing key to be reported as required without value, got %#v", e)
+	}
+}
+
+func TestErrorsMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Zip string `json:"zip" valid:"numeric"`
+	}
+	type user struct {
+		Name      string            `json:"name" valid:"stringlength(3|10)~too short"`
+		Addresses []address         `json:"addresses" valid:"dive"`
+		Labels    map[string]string `valid:"dive,alpha"`
+	}
+	_, err := ValidateStruct(user{"ab", []address{{"1"}, {"x"}}, map[string]string{"a/b": "1"}})
+	err = append(err.(Errors), fmt.Errorf("plain"))
+	actual, mErr := json.Marshal(err)
+	if mErr != nil {
+		t.Fatal(mErr)
+	}
+	expected := `[` +
+		`{"field":"/name","validator":"stringlength","code":"stringlength","message":"too short","params":["3","10"]},` +
+		`{"field":"/addresses/1/zip","validator":"numeric","code":"numeric","message":"x does not validate as numeric"},` +
+		`{"field":"/Labels/a~1b","validator":"alpha","code":"alpha","message":"1 does not validate as alpha"},` +
+		`{"field":"","message":"plain"}]`
+	if string(actual) != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+var errOutOfStock = errors.New("out of stock")
+
+type stockItem struct {
+	SKU string `valid:"required"`
+}
+
+func (s stockItem) Validate() error {
+	if s.SKU == "sold-out" {
+		return fmt.Errorf("sku %s: %w", s.SKU, errOutOfStock)
+	}
+	return nil
+}
+
+func TestErrorsUnwrap(t *testing.T) {
+	t.Parallel()
+
+	type order struct {
+		Customer string      `valid:"required"`
+		Items    []stockItem `valid:"dive"`
+	}
+	_, err := ValidateStruct(order{Items: []stockItem{{"a"}, {"sold-out"}, {""}}})
+	if !errors.Is(err, errOutOfStock) {
+		t.Errorf("expected errors.Is to find the error returned by Validate, got %v", err)
+	}
+	if !errors.Is(err, ErrRequired) || errors.Is(err, ErrInvalidTag) {
+		t.Errorf("expected errors.Is to match ErrRequired only, got %v", err)
+	}
+	var e Error
+	if !errors.As(err, &e) || e.Name != "Customer" {
+		t.Errorf("expected errors.As to find the first Error, got %v", e)
+	}
+
+	var paths []string
+	for _, e := range Flatten(err) {
+		paths = append(paths, e.Pointer())
+	}
+	if expected := []string{"/Customer", "/Items/1", "/Items/2/SKU"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+	if errs := Flatten(Errors{fmt.Errorf("plain"), &Error{Name: "n", Err: fmt.Errorf("x")}}); len(errs) != 2 ||
+		errs[0].Error() != "plain" || errs[1].Name != "n" {
+		t.Errorf("expected other errors to be kept, got %v", errs)
+	}
+	if len(Flatten(nil)) != 0 {
+		t.Error("expected no errors for nil")
+	}
+
+	_, err = ValidateStruct(struct {
+		C chan int `valid:"required"`
+	}{make(chan int)})
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+}
+
+func TestErrorsByPath(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Name string `valid:"required"`
+		Zip  string `valid:"numeric,length(5|5)"`
+	}
+	type customer struct {
+		Name      string    `valid:"required"`
+		Addresses []address `valid:"dive"`
+	}
+	addresses := make([]address, 21)
+	for i := range addresses {
+		addresses[i] = address{"home", "12345"}
+	}
+	addresses[2] = address{"", "x"}
+	addresses[20].Zip = "1"
+	vd := New(WithErrorMode(AllErrorsPerField), WithFieldNameFunc(func(f reflect.StructField) string { return f.Name }))
+	_, err := vd.ValidateStruct(customer{Addresses: addresses})
+
+	byPath := ErrorsByPath(err)
+	var tests = []struct {
+		path       string
+		validators []string
+	}{
+		{"Name", []string{"required"}},
+		{"Addresses.2.Name", []string{"required"}},
+		{"Addresses.2.Zip", []string{"numeric", "length"}},
+		{"Addresses.20.Zip", []string{"length"}},
+	}
+	for _, test := range tests {
+		var validators []string
+		for _, e := range byPath[test.path] {
+			validators = append(validators, e.Validator)
+		}
+		if !reflect.DeepEqual(validators, test.validators) {
+			t.Errorf("%s: expected %v, got %v", test.path, test.validators, validators)
+		}
+	}
+	if len(byPath) != len(tests) {
+		t.Errorf("expected %d paths, got %v", len(tests), byPath)
+	}
+
+	var paths []string
+	for _, e := range ErrorsAt(err, "Addresses.2") {
+		paths = append(paths, e.FieldPath())
+	}
+	if expected := []string{"Addresses.2.Name", "Addresses.2.Zip", "Addresses.2.Zip"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected %v, got %v", expected, paths)
+	}
+	if len(ErrorsAt(err, "Addresses")) != 4 || len(ErrorsAt(err, "")) != 5 || len(ErrorsAt(err, "Addresses.3")) != 0 {
+		t.Errorf("unexpected subtrees of %v", err)
+	}
+	if len(ErrorsByPath(nil)) != 0 {
+		t.Error("expected no errors for nil")
+	}
+}
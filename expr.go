@@ -0,0 +1,216 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// exprNode is a node of a boolean expression in a tag option, e.g. `(uuid|ulid)` or `!(email|url)&ascii`.
+// Within an option, | separates alternatives, & rules which must all pass, and parentheses group them.
+// Both operators only count outside of the parameters of a rule, so `(length(1|5)|uuid)` has two alternatives.
+type exprNode struct {
+	// op is '|', '&' or '!' for inner nodes and 0 for rules
+	op       byte
+	children []*exprNode
+	// plan holds the single rule of a leaf
+	plan *tagPlan
+}
+
+// isExpression tells whether the tag option spec is a boolean expression rather than a single rule.
+func isExpression(spec string) bool {
+	if strings.HasPrefix(spec, "(") || strings.HasPrefix(spec, "!(") {
+		return true
+	}
+	depth := 0
+	for _, c := range spec {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '|', '&':
+			if depth == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// exprParser is a recursive descent parser of the grammar
+//
+//	expr  = and { "|" and }
+//	and   = unary { "&" unary }
+//	unary = "!" unary | "(" expr ")" | rule
+type exprParser struct {
+	vd   *Validate
+	spec string
+	pos  int
+}
+
+func (vd *Validate) parseExpression(spec string) (*exprNode, error) {
+	p := &exprParser{vd: vd, spec: spec}
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.spec) {
+		return nil, fmt.Errorf("unexpected %q at offset %d of %q", p.spec[p.pos], p.pos, spec)
+	}
+	return n, nil
+}
+
+func (p *exprParser) peek() byte {
+	if p.pos < len(p.spec) {
+		return p.spec[p.pos]
+	}
+	return 0
+}
+
+func (p *exprParser) expr() (*exprNode, error) {
+	return p.binary('|', p.and)
+}
+
+func (p *exprParser) and() (*exprNode, error) {
+	return p.binary('&', p.unary)
+}
+
+func (p *exprParser) binary(op byte, operand func() (*exprNode, error)) (*exprNode, error) {
+	n, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != op {
+		return n, nil
+	}
+	node := &exprNode{op: op, children: []*exprNode{n}}
+	for p.peek() == op {
+		p.pos++
+		n, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, n)
+	}
+	return node, nil
+}
+
+func (p *exprParser) unary() (*exprNode, error) {
+	switch p.peek() {
+	case '!':
+		p.pos++
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprNode{op: '!', children: []*exprNode{n}}, nil
+	case '(':
+		p.pos++
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ) at offset %d of %q", p.pos, p.spec)
+		}
+		p.pos++
+		return n, nil
+	}
+	return p.rule()
+}
+
+// rule reads a rule up to the next operator, the parameters of the rule may contain operators.
+func (p *exprParser) rule() (*exprNode, error) {
+	start, depth := p.pos, 0
+loop:
+	for ; p.pos < len(p.spec); p.pos++ {
+		switch p.spec[p.pos] {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				break loop
+			}
+			depth--
+		case '|', '&':
+			if depth == 0 {
+				break loop
+			}
+		}
+	}
+	spec := strings.TrimSpace(p.spec[start:p.pos])
+	if spec == "" {
+		return nil, fmt.Errorf("missing rule at offset %d of %q", start, p.spec)
+	}
+	tr, err := parseOption(spec, tagOption{spec, 0})
+	if err != nil {
+		return nil, err
+	}
+	r := p.vd.resolveRule(tr)
+	return &exprNode{plan: &tagPlan{tag: spec, rules: []*rule{r}}}, nil
+}
+
+// eval evaluates the expression for the value v of the field f of o.
+// The returned error describes why the expression does not hold.
+func (vd *Validate) eval(ctx context.Context, n *exprNode, v reflect.Value, f *fieldPlan, o reflect.Value) error {
+	switch n.op {
+	case '|':
+		var msgs []string
+		for _, child := range n.children {
+			err := vd.eval(ctx, child, v, f, o)
+			if err == nil {
+				return nil
+			}
+			msgs = append(msgs, err.Error())
+		}
+		return errors.New(strings.Join(msgs, " and "))
+	case '&':
+		for _, child := range n.children {
+			if err := vd.eval(ctx, child, v, f, o); err != nil {
+				return err
+			}
+		}
+		return nil
+	case '!':
+		if err := vd.eval(ctx, n.children[0], v, f, o); err == nil {
+			return fmt.Errorf("%s does validate as %s", fmt.Sprint(v), n.children[0])
+		}
+		return nil
+	}
+	_, err := vd.typeCheck(ctx, v, &fieldPlan{name: f.name, tagPlan: n.plan}, o, nil)
+	var ruleErr Error
+	if errors.As(err, &ruleErr) && !ruleErr.CustomErrorMessageExists {
+		return ruleErr.Err
+	}
+	return err
+}
+
+// String formats the expression back into tag syntax.
+func (n *exprNode) String() string {
+	switch n.op {
+	case 0:
+		return n.plan.tag
+	case '!':
+		return "!" + n.children[0].String()
+	}
+	parts := make([]string, len(n.children))
+	for i, child := range n.children {
+		parts[i] = child.String()
+	}
+	return "(" + strings.Join(parts, string(n.op)) + ")"
+}
+
+// checkExpression evaluates the expression rule r for the value v of the field f of o.
+func (vd *Validate) checkExpression(ctx context.Context, v reflect.Value, f *fieldPlan, o reflect.Value, r *rule) error {
+	err := vd.eval(ctx, r.expr, v, f, o)
+	if err == nil {
+		return nil
+	}
+	if len(r.message) > 0 {
+		return r.messageError(f.name, v, fmt.Sprint(v), r.spec)
+	}
+	return r.newError(f.name, v, fmt.Errorf("%s does not validate as %s: %s", fmt.Sprint(v), r.spec, err), false)
+}
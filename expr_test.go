@@ -0,0 +1,71 @@
+package govalidator
+
+import (
+	"testing"
+)
+
+func TestExpressionTags(t *testing.T) {
+	t.Parallel()
+
+	type resource struct {
+		ID      string `valid:"required,(uuid|ulid)"`
+		Contact string `valid:"email|(numeric&length(5|15))"`
+		Name    string `valid:"!(email|url)"`
+		Code    string `valid:"alpha&length(2|3)|int"`
+		Port    int    `valid:"range(1|1023)|range(8000|8999)"`
+		Label   string `valid:"(uuid|ulid)~invalid label"`
+	}
+
+	valid := resource{
+		ID:      "a987fbc9-4bed-3078-cf07-9141ba07c9f3",
+		Contact: "john@example.com",
+		Name:    "John",
+		Code:    "abc",
+		Port:    8080,
+	}
+
+	var tests = []struct {
+		name     string
+		modify   func(r *resource)
+		expected string
+	}{
+		{"valid", func(r *resource) {}, ""},
+		{"ulid", func(r *resource) { r.ID = "01ARZ3NDEKTSV4RRFFQ69G5FAV" }, ""},
+		{"numeric contact", func(r *resource) { r.Contact = "0123456789" }, ""},
+		{"int code", func(r *resource) { r.Code = "42" }, ""},
+		{"neither uuid nor ulid", func(r *resource) { r.ID = "abc" },
+			"ID: abc does not validate as (uuid|ulid): abc does not validate as uuid and abc does not validate as ulid"},
+		{"short number", func(r *resource) { r.Contact = "123" },
+			"Contact: 123 does not validate as email|(numeric&length(5|15)): 123 does not validate as email and 123 does not validate as length(5|15)"},
+		{"negated group", func(r *resource) { r.Name = "john@example.com" },
+			"Name: john@example.com does not validate as !(email|url): john@example.com does validate as (email|url)"},
+		{"and binds tighter than or", func(r *resource) { r.Code = "abcd" },
+			"Code: abcd does not validate as alpha&length(2|3)|int: abcd does not validate as length(2|3) and abcd does not validate as int"},
+		{"numeric alternatives", func(r *resource) { r.Port = 2000 },
+			"Port: 2000 does not validate as range(1|1023)|range(8000|8999): 2000 does not validate as range(1|1023) and 2000 does not validate as range(8000|8999)"},
+		{"custom message", func(r *resource) { r.Label = "x" }, "invalid label"},
+	}
+	for _, test := range tests {
+		r := valid
+		test.modify(&r)
+		ok, err := ValidateStruct(r)
+		if ok != (test.expected == "") {
+			t.Errorf("%s: expected %t, got %t: %v", test.name, test.expected == "", ok, err)
+			continue
+		}
+		if test.expected != "" && err.Error() != test.expected {
+			t.Errorf("%s: expected error\n%s\ngot\n%s", test.name, test.expected, err.Error())
+		}
+	}
+}
+
+func TestMalformedExpressionTags(t *testing.T) {
+	t.Parallel()
+
+	for _, tag := range []string{"(uuid|ulid", "uuid|", "(uuid)ulid", "uuid&&ulid"} {
+		ok, err := ValidateMap(map[string]interface{}{"id": "abc"}, map[string]interface{}{"id": tag})
+		if ok || err == nil {
+			t.Errorf("expected malformed expression %q to be reported, got %t", tag, ok)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package govalidator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// fieldTagMap maps the cross-field tags to the comparison they perform. A tag like `eqfield(Password)` compares
+// the field with the sibling field Password of the struct being validated (or with the key "Password" of the
+// map passed to ValidateMap); a dotted path such as `gtfield(Period.Start)` reaches into nested structs.
+// The function is handed the result of comparing the field with the other one: -1, 0 or +1.
+var fieldTagMap = map[string]func(cmp int) bool{
+	"eqfield":  func(cmp int) bool { return cmp == 0 },
+	"nefield":  func(cmp int) bool { return cmp != 0 },
+	"gtfield":  func(cmp int) bool { return cmp > 0 },
+	"gtefield": func(cmp int) bool { return cmp >= 0 },
+	"ltfield":  func(cmp int) bool { return cmp < 0 },
+	"ltefield": func(cmp int) bool { return cmp <= 0 },
+}
+
+var rxFieldTag = regexp.MustCompile(`^(\w+)\(([\w.]+)\)$`)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// matchFieldTag checks whether validator is a cross-field tag like `eqfield(Password)`.
+func matchFieldTag(validator string) (func(int) bool, string, bool) {
+	ps := rxFieldTag.FindStringSubmatch(validator)
+	if len(ps) == 0 {
+		return nil, "", false
+	}
+	fn, ok := fieldTagMap[ps[1]]
+	return fn, ps[2], ok
+}
+
+// lookupField resolves a dotted path of field names (or map keys) starting at o,
+// dereferencing pointers and interfaces on the way.
+func lookupField(o reflect.Value, path string) (reflect.Value, bool) {
+	v := o
+	for _, name := range strings.Split(path, ".") {
+		v = indirectValue(v)
+		switch v.Kind() {
+		case reflect.Struct:
+			sf, ok := v.Type().FieldByName(name)
+			if !ok || sf.PkgPath != "" {
+				return reflect.Value{}, false
+			}
+			v = v.FieldByIndex(sf.Index)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return reflect.Value{}, false
+			}
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	return v, true
+}
+
+// indirectValue dereferences pointers and interfaces until it reaches a concrete value or nil.
+func indirectValue(v reflect.Value) reflect.Value {
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// compareValues compares two strings, booleans, numbers of any kind or time.Time values.
+// It returns -1, 0 or +1, or an error if the values cannot be compared with each other.
+func compareValues(a, b reflect.Value) (int, error) {
+	a, b = indirectValue(a), indirectValue(b)
+	if !a.IsValid() || !b.IsValid() {
+		return 0, fmt.Errorf("cannot compare invalid values")
+	}
+	switch {
+	case a.Type() == timeType && b.Type() == timeType:
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time)), nil
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), nil
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0, nil
+		case b.Bool():
+			return -1, nil
+		}
+		return 1, nil
+	case isNumberKind(a.Kind()) && isNumberKind(b.Kind()):
+		return compareNumbers(a, b), nil
+	}
+	return 0, fmt.Errorf("cannot compare %s with %s", a.Type(), b.Type())
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// compareNumbers compares two numbers of any kind without losing precision on large integers.
+func compareNumbers(a, b reflect.Value) int {
+	isFloat := func(k reflect.Kind) bool { return k == reflect.Float32 || k == reflect.Float64 }
+	isInt := func(k reflect.Kind) bool { return k >= reflect.Int && k <= reflect.Int64 }
+	switch {
+	case isFloat(a.Kind()) || isFloat(b.Kind()):
+		return compareOrdered(toFloat64(a), toFloat64(b))
+	case isInt(a.Kind()) && isInt(b.Kind()):
+		return compareOrdered(a.Int(), b.Int())
+	case !isInt(a.Kind()) && !isInt(b.Kind()):
+		return compareOrdered(a.Uint(), b.Uint())
+	case isInt(a.Kind()):
+		if a.Int() < 0 {
+			return -1
+		}
+		return compareOrdered(uint64(a.Int()), b.Uint())
+	default:
+		if b.Int() < 0 {
+			return 1
+		}
+		return compareOrdered(a.Uint(), uint64(b.Int()))
+	}
+}
+
+func toFloat64(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	}
+	return float64(v.Uint())
+}
+
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// checkField evaluates the cross-field rule r for the value v of the field f of o.
+func checkField(v reflect.Value, f *fieldPlan, o reflect.Value, r *rule) error {
+	other, ok := lookupField(o, r.params[0])
+	if !ok {
+		e := r.newError(f.name, v, fmt.Errorf("field %s referenced by %s does not exist", r.params[0], r.validator), false)
+		e.Code = CodeInvalidValidator
+		return e
+	}
+	isNil := func(v reflect.Value) bool {
+		v = indirectValue(v)
+		return v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface
+	}
+	if isNil(v) || isNil(other) {
+		// one of the fields is nil, so they are neither equal to nor comparable with each other
+		if r.negate {
+			return nil
+		}
+		return ruleError(f, r, v, fmt.Sprint(indirectValue(v)))
+	}
+	cmp, err := compareValues(v, other)
+	if err != nil {
+		e := r.newError(f.name, v, fmt.Errorf("%s: %s", r.validator, err), false)
+		e.Code = CodeUnsupportedKind
+		return e
+	}
+	if r.fieldFn(cmp) == r.negate {
+		return ruleError(f, r, v, fmt.Sprint(indirectValue(v)))
+	}
+	return nil
+}
+
+// conditionTagMap maps the conditional tags to the condition they test on the struct (or map) being validated.
+// The required_* tags make an empty field invalid when their condition holds, excluded_if makes a non-empty
+// field invalid when its condition holds. The parameters are field names, or for required_if, required_unless
+// and excluded_if pairs of a field name and the value it is compared with, e.g. `required_if(Country|US)`.
+var conditionTagMap = map[string]func(o reflect.Value, params []string) bool{
+	"required_if": func(o reflect.Value, params []string) bool {
+		return fieldsEqual(o, params)
+	},
+	"required_unless": func(o reflect.Value, params []string) bool {
+		return !fieldsEqual(o, params)
+	},
+	"required_with": func(o reflect.Value, params []string) bool {
+		for _, name := range params {
+			if fieldPresent(o, name) {
+				return true
+			}
+		}
+		return false
+	},
+	"required_with_all": func(o reflect.Value, params []string) bool {
+		for _, name := range params {
+			if !fieldPresent(o, name) {
+				return false
+			}
+		}
+		return true
+	},
+	"required_without": func(o reflect.Value, params []string) bool {
+		for _, name := range params {
+			if !fieldPresent(o, name) {
+				return true
+			}
+		}
+		return false
+	},
+	"excluded_if": func(o reflect.Value, params []string) bool {
+		return fieldsEqual(o, params)
+	},
+}
+
+var rxConditionTag = regexp.MustCompile(`^(\w+)\((.+)\)$`)
+
+// matchConditionTag checks whether validator is a conditional tag like `required_if(Country|US)`.
+func matchConditionTag(validator string) (func(reflect.Value, []string) bool, string, []string, bool) {
+	ps := rxConditionTag.FindStringSubmatch(validator)
+	if len(ps) == 0 {
+		return nil, "", nil, false
+	}
+	fn, ok := conditionTagMap[ps[1]]
+	if !ok {
+		return nil, "", nil, false
+	}
+	params := strings.Split(ps[2], "|")
+	switch ps[1] {
+	case "required_if", "required_unless", "excluded_if":
+		if len(params)%2 != 0 {
+			return nil, "", nil, false
+		}
+	}
+	return fn, ps[1], params, true
+}
+
+// fieldsEqual checks that every field of the field/value pairs in params has the given value.
+func fieldsEqual(o reflect.Value, params []string) bool {
+	for i := 0; i+1 < len(params); i += 2 {
+		v, ok := lookupField(o, params[i])
+		if !ok {
+			return false
+		}
+		v = indirectValue(v)
+		if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			return false
+		}
+		if fmt.Sprint(v) != params[i+1] {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldPresent checks that the field exists and is not empty.
+func fieldPresent(o reflect.Value, name string) bool {
+	v, ok := lookupField(o, name)
+	return ok && !isEmptyValue(v)
+}
+
+// requiredByCondition returns the first conditional required rule of f whose condition holds.
+func requiredByCondition(f *fieldPlan, o reflect.Value) *rule {
+	for _, r := range f.rules {
+		if r.kind == ruleRequiredIf && r.conditionFn(o, r.params) {
+			return r
+		}
+	}
+	return nil
+}
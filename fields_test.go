@@ -0,0 +1,276 @@
+package govalidator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCrossFieldTags(t *testing.T) {
+	t.Parallel()
+
+	type period struct {
+		Start time.Time
+		End   time.Time `valid:"gtfield(Start)"`
+	}
+
+	type account struct {
+		Password        string     `valid:"required"`
+		PasswordConfirm string     `valid:"eqfield(Password)"`
+		Username        string     `valid:"nefield(Password)"`
+		Min             int8       `valid:"-"`
+		Max             uint64     `valid:"gtefield(Min)"`
+		Ratio           float64    `valid:"ltfield(Max)"`
+		Limit           *int       `valid:"ltefield(Max)"`
+		Period          period     `valid:"-"`
+		Renewal         *time.Time `valid:"gtfield(Period.End)"`
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limit := 10
+	bigLimit := 11
+	later := now.Add(48 * time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	valid := account{
+		Password:        "secret",
+		PasswordConfirm: "secret",
+		Username:        "john",
+		Min:             -5,
+		Max:             10,
+		Ratio:           9.5,
+		Limit:           &limit,
+		Renewal:         &later,
+	}
+
+	var tests = []struct {
+		name      string
+		modify    func(a *account)
+		expected  bool
+		validator string
+	}{
+		{"valid", func(a *account) {}, true, ""},
+		{"confirm differs", func(a *account) { a.PasswordConfirm = "other" }, false, "eqfield"},
+		{"username equals password", func(a *account) { a.Username = "secret" }, false, "nefield"},
+		{"uint below negative int", func(a *account) { a.Min = 11 }, false, "gtefield"},
+		{"float above uint", func(a *account) { a.Ratio = 10.5 }, false, "ltfield"},
+		{"pointer above uint", func(a *account) { a.Limit = &bigLimit }, false, "ltefield"},
+		{"nested time before", func(a *account) { a.Period.End = now.Add(24 * time.Hour); a.Renewal = &earlier }, false, "gtfield"},
+	}
+	for _, test := range tests {
+		a := valid
+		test.modify(&a)
+		actual, err := ValidateStruct(a)
+		if actual != test.expected {
+			t.Errorf("%s: expected ValidateStruct to be %v, got %v (%v)", test.name, test.expected, actual, err)
+			continue
+		}
+		if !test.expected {
+			errs := err.(Errors)
+			if len(errs) != 1 || errs[0].(Error).Validator != test.validator {
+				t.Errorf("%s: expected a single %s error, got %v", test.name, test.validator, err)
+			}
+		}
+	}
+
+	if ok, err := ValidateStruct(period{Start: now, End: earlier}); ok || err == nil {
+		t.Errorf("Expected End before Start to fail, got %v %v", ok, err)
+	}
+}
+
+func TestCrossFieldTagErrors(t *testing.T) {
+	t.Parallel()
+
+	type invalid struct {
+		Name   string `valid:"eqfield(Missing)"`
+		Number int    `valid:"eqfield(Name)"`
+		Other  string `valid:"!eqfield(Name)"`
+	}
+
+	_, err := ValidateStruct(invalid{Name: "a", Number: 1, Other: "a"})
+	if err == nil {
+		t.Fatal("Expected errors")
+	}
+	expected := "Name: field Missing referenced by eqfield(Missing) does not exist;" +
+		"Number: eqfield(Name): cannot compare int with string;" +
+		"Other: a does validate as eqfield(Name)"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestCrossFieldTagsOnElements(t *testing.T) {
+	t.Parallel()
+
+	type limits struct {
+		Max    int
+		Values []int    `valid:"ltfield(Max)"`
+		Names  []string `valid:"alpha,nefield(Banned)"`
+		Banned string
+	}
+
+	var tests = []struct {
+		param    limits
+		expected string
+	}{
+		{limits{Max: 5, Values: []int{1, 2}, Names: []string{"a", "b"}, Banned: "c"}, ""},
+		{limits{Max: 5, Values: []int{1, 7}}, "Values: 7 does not validate as ltfield(Max)"},
+		{limits{Max: 5, Names: []string{"", "a", "c"}, Banned: "c"}, "Names: c does not validate as nefield(Banned)"},
+	}
+	for _, test := range tests {
+		_, err := ValidateStruct(test.param)
+		actual := ""
+		if err != nil {
+			actual = err.Error()
+		}
+		if actual != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, actual)
+		}
+	}
+}
+
+func TestCrossFieldTagsInMap(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]interface{}{
+		"password": "required",
+		"confirm":  "eqfield(password)",
+	}
+	if ok, err := ValidateMap(map[string]interface{}{"password": "a", "confirm": "a"}, schema); !ok || err != nil {
+		t.Errorf("Expected map to be valid, got %v %v", ok, err)
+	}
+	if ok, _ := ValidateMap(map[string]interface{}{"password": "a", "confirm": "b"}, schema); ok {
+		t.Error("Expected map to be invalid")
+	}
+}
+
+func TestConditionalRequiredTags(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Country  string `valid:"required"`
+		State    string `valid:"required_if(Country|US)"`
+		Province string `valid:"required_unless(Country|US)"`
+		Phone    string `valid:"-"`
+		Email    string `valid:"required_without(Phone)"`
+		Fax      string `valid:"required_with(Phone|Email)"`
+		Backup   string `valid:"required_with_all(Phone|Email)"`
+		Zip      string `valid:"excluded_if(Country|XX)"`
+	}
+
+	var tests = []struct {
+		param     address
+		validator string
+	}{
+		{address{Country: "US", State: "CA", Email: "a", Fax: "f"}, ""},
+		{address{Country: "US", Email: "a", Fax: "f"}, "required_if"},
+		{address{Country: "DE", Province: "BY", Email: "a", Fax: "f"}, ""},
+		{address{Country: "DE", Email: "a", Fax: "f"}, "required_unless"},
+		{address{Country: "US", State: "CA", Phone: "1", Fax: "f"}, ""},
+		{address{Country: "US", State: "CA", Fax: "f"}, "required_without"},
+		{address{Country: "US", State: "CA", Email: "a"}, "required_with"},
+		{address{Country: "US", State: "CA", Phone: "1", Email: "a", Fax: "f"}, "required_with_all"},
+		{address{Country: "XX", Province: "P", Email: "a", Fax: "f", Zip: "123"}, "excluded_if"},
+		{address{Country: "XX", Province: "P", Email: "a", Fax: "f"}, ""},
+	}
+	for _, test := range tests {
+		ok, err := ValidateStruct(test.param)
+		if test.validator == "" {
+			if !ok || err != nil {
+				t.Errorf("Expected %+v to be valid, got %v", test.param, err)
+			}
+			continue
+		}
+		if ok || err == nil {
+			t.Errorf("Expected %+v to be invalid", test.param)
+			continue
+		}
+		errs := err.(Errors)
+		if len(errs) != 1 || errs[0].(Error).Validator != test.validator {
+			t.Errorf("Expected a single %s error for %+v, got %v", test.validator, test.param, err)
+		}
+	}
+}
+
+func TestConditionalRequiredTagsInMap(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]interface{}{
+		"country": "required",
+		"state":   "required_if(country|US)~State is required in the US",
+		"phone":   "numeric",
+		"email":   "required_without(phone)",
+	}
+
+	if ok, err := ValidateMap(map[string]interface{}{"country": "DE", "phone": "123"}, schema); !ok || err != nil {
+		t.Errorf("Expected map to be valid, got %v", err)
+	}
+
+	ok, err := ValidateMap(map[string]interface{}{"country": "US"}, schema)
+	if ok || err == nil {
+		t.Fatal("Expected map to be invalid")
+	}
+	if msg := ErrorByField(err, "state"); msg != "State is required in the US" {
+		t.Errorf("Expected custom message for state, got %q", msg)
+	}
+	validators := map[string]string{}
+	for _, e := range err.(Errors) {
+		validators[e.(Error).Name] = e.(Error).Validator
+	}
+	if validators["state"] != "required_if" || validators["email"] != "required_without" {
+		t.Errorf("Expected conditional validators in errors, got %v", validators)
+	}
+
+	ok, err = ValidateMap(map[string]interface{}{"country": "US", "state": "", "phone": "1"}, schema)
+	if ok || ErrorByField(err, "state") != "State is required in the US" {
+		t.Errorf("Expected empty state to be required, got %v", err)
+	}
+}
+
+func TestConditionalRequiredByDefault(t *testing.T) {
+	t.Parallel()
+
+	type example struct {
+		Country string `valid:"optional"`
+		State   string `valid:"required_if(Country|US)"`
+	}
+
+	vd := New(WithFieldsRequiredByDefault(true))
+	if ok, err := vd.ValidateStruct(example{}); !ok || err != nil {
+		t.Errorf("Expected conditionally required field to be exempt from required by default, got %v", err)
+	}
+}
+
+func TestCrossFieldTagsOnEmptyFields(t *testing.T) {
+	t.Parallel()
+
+	type account struct {
+		Password string  `valid:"-"`
+		Confirm  string  `valid:"eqfield(Password)"`
+		Username string  `valid:"nefield(Password)"`
+		Min      int     `valid:"-"`
+		Max      int     `valid:"gtfield(Min)"`
+		Limit    *int    `valid:"ltfield(Min)"`
+		Hint     *string `valid:"!eqfield(Password)"`
+	}
+
+	var tests = []struct {
+		param    account
+		expected string
+	}{
+		{account{}, ""},
+		{account{Password: "secret", Confirm: "secret"}, ""},
+		{account{Password: "secret"}, "Confirm:  does not validate as eqfield(Password)"},
+		{account{Password: "secret", Confirm: "secret", Min: 5}, "Max: 0 does not validate as gtfield(Min);Limit: <nil> does not validate as ltfield(Min)"},
+		{account{Min: -5}, "Limit: <nil> does not validate as ltfield(Min)"},
+	}
+	for _, test := range tests {
+		_, err := ValidateStruct(test.param)
+		actual := ""
+		if err != nil {
+			actual = err.Error()
+		}
+		if actual != test.expected {
+			t.Errorf("Expected ValidateStruct(%+v) to fail with %q, got %q", test.param, test.expected, actual)
+		}
+	}
+}
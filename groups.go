@@ -0,0 +1,81 @@
+package govalidator
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// rxGroups matches the groups a rule belongs to, e.g. "@create" in `required@create`
+// or "@create|update" in `uuid@create|update`.
+var rxGroups = regexp.MustCompile(`^(.+)@(\w+(?:\|\w+)*)$`)
+
+// splitGroups splits a tag option into the rule and the validation groups it belongs to.
+// Ungrouped rules have no groups.
+func splitGroups(option string) (string, []string) {
+	ps := rxGroups.FindStringSubmatch(option)
+	if len(ps) == 0 {
+		return option, nil
+	}
+	return ps[1], strings.Split(ps[2], "|")
+}
+
+// groupsActive tells whether a rule of the given groups is applied when the groups of active,
+// a comma separated list as built by groupsKey, are validated.
+func groupsActive(groups []string, active string) bool {
+	if len(groups) == 0 {
+		return true
+	}
+	for _, name := range strings.Split(active, ",") {
+		for _, group := range groups {
+			if name == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// groupsKey builds a canonical key of a set of groups, used to cache the plans built for it.
+func groupsKey(groups []string) string {
+	sorted := append([]string(nil), groups...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+type groupsContextKey struct{}
+
+// ContextWithGroups returns a copy of ctx which makes ValidateStructCtx and ValidateMapCtx apply the rules
+// of the given validation groups, along with the ungrouped rules.
+func ContextWithGroups(ctx context.Context, groups ...string) context.Context {
+	return context.WithValue(ctx, groupsContextKey{}, groupsKey(groups))
+}
+
+func groupsFrom(ctx context.Context) string {
+	groups, _ := ctx.Value(groupsContextKey{}).(string)
+	return groups
+}
+
+// ValidateStructGroups validates s with the rules of the given validation groups and the ungrouped rules.
+// A rule belongs to groups when they follow it after an @, e.g. `valid:"required@create,uuid@update|delete"`.
+// The groups apply to nested structs as well.
+func ValidateStructGroups[T any](s T, groups ...string) (bool, error) {
+	return defaultValidate.ValidateStructGroups(s, groups...)
+}
+
+// ValidateStructGroups is the instance counterpart of the package level ValidateStructGroups.
+func (vd *Validate) ValidateStructGroups(s any, groups ...string) (bool, error) {
+	return vd.validateStruct(ContextWithGroups(context.Background(), groups...), s)
+}
+
+// ValidateMapGroups validates s with the rules of the given validation groups and the ungrouped rules
+// of the validation map m, see ValidateStructGroups.
+func ValidateMapGroups(s map[string]interface{}, m map[string]interface{}, groups ...string) (bool, error) {
+	return defaultValidate.ValidateMapGroups(s, m, groups...)
+}
+
+// ValidateMapGroups is the instance counterpart of the package level ValidateMapGroups.
+func (vd *Validate) ValidateMapGroups(s map[string]interface{}, m map[string]interface{}, groups ...string) (bool, error) {
+	return vd.validateMap(ContextWithGroups(context.Background(), groups...), s, m)
+}
@@ -0,0 +1,89 @@
+package govalidator
+
+import (
+	"testing"
+)
+
+func TestValidateStructGroups(t *testing.T) {
+	t.Parallel()
+
+	type profile struct {
+		Nickname string `valid:"required@create,alpha"`
+	}
+	type user struct {
+		ID       string `valid:"required@update|delete,uuid@update|delete"`
+		Password string `valid:"required@create,minstringlength(8)"`
+		Email    string `valid:"email"`
+		Profile  profile
+	}
+
+	const id = "a987fbc9-4bed-3078-cf07-9141ba07c9f3"
+	var tests = []struct {
+		name      string
+		param     user
+		groups    []string
+		expected  bool
+		validator string
+	}{
+		{"create", user{Password: "password1", Profile: profile{"john"}}, []string{"create"}, true, ""},
+		{"create without password", user{Profile: profile{"john"}}, []string{"create"}, false, "required"},
+		{"create without nickname", user{Password: "password1"}, []string{"create"}, false, "required"},
+		{"update", user{ID: id}, []string{"update"}, true, ""},
+		{"update without id", user{Password: "password1"}, []string{"update"}, false, "required"},
+		{"update with invalid id", user{ID: "1"}, []string{"update"}, false, "uuid"},
+		{"update with short password", user{ID: id, Password: "short"}, []string{"update"}, false, "minstringlength"},
+		{"ungrouped rules", user{Email: "invalid"}, nil, false, "email"},
+		{"no groups", user{}, nil, true, ""},
+		{"several groups", user{ID: id, Password: "password1"}, []string{"create", "update"}, false, "required"},
+	}
+	for _, test := range tests {
+		ok, err := ValidateStructGroups(test.param, test.groups...)
+		if ok != test.expected {
+			t.Errorf("%s: expected %t, got %t: %v", test.name, test.expected, ok, err)
+			continue
+		}
+		if test.validator != "" {
+			errs := flattenErrors(err)
+			if len(errs) != 1 || errs[0].(Error).Validator != test.validator {
+				t.Errorf("%s: expected a single %s error, got %v", test.name, test.validator, err)
+			}
+		}
+	}
+}
+
+func TestValidateMapGroups(t *testing.T) {
+	t.Parallel()
+
+	template := map[string]interface{}{
+		"id":   "required@update,uuid",
+		"name": "required@create,alpha",
+	}
+	if ok, err := ValidateMapGroups(map[string]interface{}{"name": "john"}, template, "create"); !ok || err != nil {
+		t.Errorf("expected map to be valid on create, got %v", err)
+	}
+	if ok, _ := ValidateMapGroups(map[string]interface{}{"name": "john"}, template, "update"); ok {
+		t.Error("expected map without id to be invalid on update")
+	}
+}
+
+func TestSplitGroups(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		option string
+		rule   string
+		groups []string
+	}{
+		{"required", "required", nil},
+		{"required@create", "required", []string{"create"}},
+		{"length(1|5)@create|update", "length(1|5)", []string{"create", "update"}},
+		{"matches(^.+@.+$)", "matches(^.+@.+$)", nil},
+		{"matches(^.+@example)@create", "matches(^.+@example)", []string{"create"}},
+	}
+	for _, test := range tests {
+		rule, groups := splitGroups(test.option)
+		if rule != test.rule || len(groups) != len(test.groups) {
+			t.Errorf("splitGroups(%q): expected %q %v, got %q %v", test.option, test.rule, test.groups, rule, groups)
+		}
+	}
+}
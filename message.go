@@ -0,0 +1,100 @@
+package govalidator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// rxPlaceholder matches the named placeholders of custom error messages and catalog templates.
+var rxPlaceholder = regexp.MustCompile(`\{(field|path|value|validator|len|param\d+)\}`)
+
+// expandPlaceholders replaces the named placeholders of the template t by the details of e:
+//
+//	{field}     the name of the field, e.g. "Zip"
+//	{path}      the dotted path of the field, e.g. "Addresses.2.Zip"
+//	{value}     the offending value
+//	{validator} the name of the validator, e.g. "stringlength"
+//	{len}       the length of a string (in runes), slice, array or map value
+//	{param0}    the first parameter of the rule, {param1} the second one and so on
+//
+// Placeholders of parameters the rule does not have are left alone.
+func (e Error) expandPlaceholders(t string) string {
+	return rxPlaceholder.ReplaceAllStringFunc(t, func(p string) string {
+		switch name := p[1 : len(p)-1]; name {
+		case "field":
+			if e.Name == "" && len(e.Path) > 0 {
+				return e.Path[len(e.Path)-1]
+			}
+			return e.Name
+		case "path":
+			return e.FieldPath()
+		case "value":
+			return formatValue(e.Value)
+		case "validator":
+			return e.Validator
+		case "len":
+			return valueLen(e.Value)
+		default:
+			if i, err := strconv.Atoi(strings.TrimPrefix(name, "param")); err == nil && i < len(e.Param) {
+				return e.Param[i]
+			}
+			return p
+		}
+	})
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
+
+func valueLen(value any) string {
+	if s, ok := value.(string); ok {
+		return strconv.Itoa(utf8.RuneCountInString(s))
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return strconv.Itoa(v.Len())
+	}
+	return ""
+}
+
+// customMessage is the Err of an Error whose custom message uses named placeholders, or which was translated.
+// It is rendered again whenever the Error is renamed or moved below another field, so that {field} and {path}
+// stay accurate, and it is printed without the name of the field.
+type customMessage struct {
+	template string
+	text     string
+}
+
+func (m *customMessage) Error() string {
+	return m.text
+}
+
+// customMessageError renders the custom message msg of the Error e. Messages with named placeholders are
+// rendered with the details of e, see expandPlaceholders, while legacy messages get the value formatted as
+// str and the validator for their %s verbs.
+func customMessageError(msg string, e Error, str, validator string) error {
+	if !rxPlaceholder.MatchString(msg) {
+		return TruncatingErrorf(msg, str, validator)
+	}
+	return &customMessage{template: msg, text: e.expandPlaceholders(msg)}
+}
+
+// rerender renders the custom message of e again after its name or path changed.
+func (e Error) rerender() Error {
+	if m, ok := e.Err.(*customMessage); ok {
+		e.Err = &customMessage{template: m.template, text: e.expandPlaceholders(m.template)}
+	}
+	return e
+}
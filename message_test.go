@@ -0,0 +1,53 @@
+package govalidator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCustomMessagePlaceholders(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Zip string `json:"zip" valid:"numeric~{path} must be numeric\\, got {value}"`
+	}
+	type user struct {
+		Name      string    `json:"name" valid:"maxstringlength(5)~{field} must be at most {param0} characters\\, got {len}"`
+		Email     string    `json:"email" valid:"required~{field} is required"`
+		Role      string    `valid:"in(a|b)~{validator}: {value} is not {param0} or {param1}{param2}"`
+		Legacy    string    `valid:"alpha~%s does not validate as %s"`
+		Overflow  string    `valid:"alpha~%s %s %s"`
+		Tags      []string  `valid:"maxitems(1)~{field} has {len} items"`
+		Addresses []address `json:"addresses" valid:"dive"`
+	}
+	param := user{
+		Name: "Gopher!", Role: "c", Legacy: "1", Overflow: "2",
+		Tags: []string{"a", "b"}, Addresses: []address{{"1"}, {"x"}},
+	}
+	expected := map[string]string{
+		"name":     "name must be at most 5 characters, got 7",
+		"email":    "email is required",
+		"Role":     "in: c is not a or b{param2}",
+		"Legacy":   "1 does not validate as alpha",
+		"Overflow": "2 alpha %!s(MISSING)",
+		"Tags":     "Tags has 2 items",
+		"zip":      "addresses.1.zip must be numeric, got x",
+	}
+	_, err := New(WithErrorMode(AllErrorsPerField)).ValidateStruct(param)
+	errs := flattenErrors(err)
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), errs)
+	}
+	for _, e := range errs {
+		if name := e.(Error).Name; e.Error() != expected[name] {
+			t.Errorf("%s: expected %q, got %q", name, expected[name], e.Error())
+		}
+	}
+
+	_, err = New(WithFieldNameFunc(func(f reflect.StructField) string { return strings.ToUpper(f.Name) })).ValidateStruct(param)
+	messages := ErrorsByField(err)
+	if messages["ZIP"] != "ADDRESSES.1.ZIP must be numeric, got x" {
+		t.Errorf("expected the path to be rendered with resolved names, got %v", messages)
+	}
+}
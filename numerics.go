@@ -2,6 +2,10 @@ package govalidator
 
 import (
 	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
 
 	"golang.org/x/exp/constraints"
 )
@@ -60,3 +64,174 @@ func IsWhole[V constraints.Integer | constraints.Float](value V) bool {
 func IsNatural[V constraints.Integer | constraints.Float](value V) bool {
 	return IsWhole(value) && IsPositive(value)
 }
+
+// numberParam is a numeric tag parameter, parsed once when the validation plan is built.
+type numberParam struct {
+	rat *big.Rat
+	// i and u hold the parameter when it is a whole number fitting into int64 or uint64
+	i         int64
+	isInt64   bool
+	u         uint64
+	isUint64  bool
+	f         float64
+	isFloat64 bool // f represents the parameter exactly
+}
+
+var rxNumberParam = regexp.MustCompile(`^[-+]?\d+(?:\.\d+)?(?:[eE][-+]?\d+)?$`)
+
+// parseNumberParam parses integers and decimals such as "-12", "0.5" or "1e3" without rounding.
+func parseNumberParam(s string) (numberParam, bool) {
+	if !rxNumberParam.MatchString(s) {
+		return numberParam{}, false
+	}
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return numberParam{}, false
+	}
+	p := numberParam{rat: rat}
+	if rat.IsInt() {
+		if num := rat.Num(); num.IsInt64() {
+			p.i, p.isInt64 = num.Int64(), true
+		}
+		if num := rat.Num(); num.IsUint64() {
+			p.u, p.isUint64 = num.Uint64(), true
+		}
+	}
+	p.f, p.isFloat64 = rat.Float64()
+	return p, true
+}
+
+// numberToRat converts a numeric value of any kind to an exact rational number.
+func numberToRat(v reflect.Value) *big.Rat {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int())
+	case reflect.Float32, reflect.Float64:
+		return new(big.Rat).SetFloat64(v.Float())
+	}
+	return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint()))
+}
+
+// compareNumberParam compares the numeric value v with p using exact arithmetic.
+// ok is false if v is NaN, which cannot be compared with anything.
+func compareNumberParam(v reflect.Value, p numberParam) (cmp int, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if p.isInt64 {
+			return compareOrdered(v.Int(), p.i), true
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if p.isUint64 {
+			return compareOrdered(v.Uint(), p.u), true
+		}
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		switch {
+		case math.IsNaN(f):
+			return 0, false
+		case math.IsInf(f, 0):
+			return int(Sign(f)), true
+		case p.isFloat64:
+			return compareOrdered(f, p.f), true
+		}
+	}
+	return numberToRat(v).Cmp(p.rat), true
+}
+
+// numberTagMap maps the numeric tags to their implementation. They are applied to the value of
+// int, uint and float fields directly, instead of its string representation.
+var numberTagMap = map[string]func(v reflect.Value, params []numberParam) bool{
+	"min": func(v reflect.Value, params []numberParam) bool {
+		cmp, ok := compareNumberParam(v, params[0])
+		return ok && cmp >= 0
+	},
+	"max": func(v reflect.Value, params []numberParam) bool {
+		cmp, ok := compareNumberParam(v, params[0])
+		return ok && cmp <= 0
+	},
+	"gt": func(v reflect.Value, params []numberParam) bool {
+		cmp, ok := compareNumberParam(v, params[0])
+		return ok && cmp > 0
+	},
+	"gte": func(v reflect.Value, params []numberParam) bool {
+		cmp, ok := compareNumberParam(v, params[0])
+		return ok && cmp >= 0
+	},
+	"lt": func(v reflect.Value, params []numberParam) bool {
+		cmp, ok := compareNumberParam(v, params[0])
+		return ok && cmp < 0
+	},
+	"lte": func(v reflect.Value, params []numberParam) bool {
+		cmp, ok := compareNumberParam(v, params[0])
+		return ok && cmp <= 0
+	},
+	"range": func(v reflect.Value, params []numberParam) bool {
+		left, right := params[0], params[1]
+		if left.rat.Cmp(right.rat) > 0 {
+			left, right = right, left
+		}
+		cmpLeft, ok := compareNumberParam(v, left)
+		cmpRight, _ := compareNumberParam(v, right)
+		return ok && cmpLeft >= 0 && cmpRight <= 0
+	},
+	"multipleof": func(v reflect.Value, params []numberParam) bool {
+		p := params[0]
+		if p.rat.Sign() == 0 {
+			return false
+		}
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if p.isInt64 {
+				return v.Int()%p.i == 0
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if p.isUint64 {
+				return v.Uint()%p.u == 0
+			}
+		case reflect.Float32, reflect.Float64:
+			if f := v.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+				return false
+			}
+		}
+		return new(big.Rat).Quo(numberToRat(v), p.rat).IsInt()
+	},
+	"finite": func(v reflect.Value, params []numberParam) bool {
+		if v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64 {
+			return !math.IsNaN(v.Float()) && !math.IsInf(v.Float(), 0)
+		}
+		return true
+	},
+}
+
+// numberTagParams is the number of parameters each numeric tag expects.
+var numberTagParams = map[string]int{
+	"min": 1, "max": 1, "gt": 1, "gte": 1, "lt": 1, "lte": 1, "range": 2, "multipleof": 1, "finite": 0,
+}
+
+var rxNumberTag = regexp.MustCompile(`^(\w+)(?:\((.*)\))?$`)
+
+// matchNumberTag checks whether validator is a numeric tag like `min(-1.5)` and parses its parameters.
+func matchNumberTag(validator string) (func(reflect.Value, []numberParam) bool, []string, []numberParam, bool) {
+	ps := rxNumberTag.FindStringSubmatch(validator)
+	if len(ps) == 0 {
+		return nil, nil, nil, false
+	}
+	fn, ok := numberTagMap[ps[1]]
+	if !ok {
+		return nil, nil, nil, false
+	}
+	var raw []string
+	if ps[2] != "" {
+		raw = strings.Split(ps[2], "|")
+	}
+	if len(raw) != numberTagParams[ps[1]] {
+		return nil, nil, nil, false
+	}
+	params := make([]numberParam, len(raw))
+	for i, s := range raw {
+		if params[i], ok = parseNumberParam(s); !ok {
+			return nil, nil, nil, false
+		}
+	}
+	return fn, raw, params, true
+}
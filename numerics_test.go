@@ -1,6 +1,9 @@
 package govalidator
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestAbs(t *testing.T) {
 	t.Parallel()
@@ -269,3 +272,106 @@ func TestInRange(t *testing.T) {
 		}
 	}
 }
+
+func TestNumberTags(t *testing.T) {
+	t.Parallel()
+
+	type numbers struct {
+		Age      int     `valid:"min(18),max(130)"`
+		Balance  float64 `valid:"gte(-100.5),lt(1e6)"`
+		Big      uint64  `valid:"gt(18446744073709551614)"`
+		Signed   int64   `valid:"lte(-9223372036854775807)"`
+		Step     int     `valid:"multipleof(5)"`
+		Price    float64 `valid:"multipleof(0.25),finite"`
+		Temp     int8    `valid:"range(-40|-10)"`
+		Negative int     `valid:"!gt(0)"`
+	}
+
+	valid := numbers{
+		Age:      30,
+		Balance:  -100.5,
+		Big:      math.MaxUint64,
+		Signed:   math.MinInt64,
+		Step:     15,
+		Price:    2.75,
+		Temp:     -20,
+		Negative: -1,
+	}
+
+	var tests = []struct {
+		modify    func(n *numbers)
+		validator string
+	}{
+		{func(n *numbers) {}, ""},
+		{func(n *numbers) { n.Age = 17 }, "min"},
+		{func(n *numbers) { n.Age = 131 }, "max"},
+		{func(n *numbers) { n.Balance = -100.51 }, "gte"},
+		{func(n *numbers) { n.Balance = 1e6 }, "lt"},
+		{func(n *numbers) { n.Big = math.MaxUint64 - 1 }, "gt"},
+		{func(n *numbers) { n.Signed = math.MinInt64 + 2 }, "lte"},
+		{func(n *numbers) { n.Step = 16 }, "multipleof"},
+		{func(n *numbers) { n.Price = 2.8 }, "multipleof"},
+		{func(n *numbers) { n.Price = math.Inf(1) }, "multipleof"},
+		{func(n *numbers) { n.Temp = -41 }, "range"},
+		{func(n *numbers) { n.Negative = 1 }, "!gt"},
+	}
+	for i, test := range tests {
+		n := valid
+		test.modify(&n)
+		ok, err := ValidateStruct(n)
+		if test.validator == "" {
+			if !ok || err != nil {
+				t.Errorf("Case %d: expected valid, got %v", i, err)
+			}
+			continue
+		}
+		if ok || err == nil {
+			t.Errorf("Case %d: expected %s to fail", i, test.validator)
+			continue
+		}
+		if v := err.(Errors)[0].(Error).Validator; v != test.validator {
+			t.Errorf("Case %d: expected %s to fail, got %v", i, test.validator, err)
+		}
+	}
+}
+
+func TestNumberTagsUnsupported(t *testing.T) {
+	t.Parallel()
+
+	type invalid struct {
+		Name  string `valid:"min(1)"`
+		Count int    `valid:"min(one)"`
+	}
+
+	_, err := ValidateStruct(invalid{Name: "a", Count: 1})
+	expected := `Name: Validator min(1) doesn't support kind string;` +
+		`Count: The following validator is invalid or can't be applied to the field: "min(one)"`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected %q, got %v", expected, err)
+	}
+
+	type finite struct {
+		Value float64 `valid:"finite"`
+	}
+	if ok, _ := ValidateStruct(finite{math.NaN()}); ok {
+		t.Error("Expected NaN not to be finite")
+	}
+}
+
+func TestRangeWithNegativeAndDecimalParams(t *testing.T) {
+	t.Parallel()
+
+	if !Range("-1.5", "-2", "-1") {
+		t.Error("Expected Range to accept negative parameters")
+	}
+
+	type ranged struct {
+		Value string `valid:"range(-2.5|-1)"`
+	}
+	if ok, err := ValidateStruct(ranged{"-2"}); !ok || err != nil {
+		t.Errorf("Expected -2 to be in range(-2.5|-1), got %v", err)
+	}
+	if ok, _ := ValidateStruct(ranged{"-3"}); ok {
+		t.Error("Expected -3 not to be in range(-2.5|-1)")
+	}
+}
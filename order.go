@@ -0,0 +1,98 @@
+package govalidator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mapKeys returns the keys of the validated map s and of its validation map m in sorted order,
+// so that ValidateMap reports errors in a stable order.
+func mapKeys(s, m map[string]interface{}) []string {
+	keys := make([]string, 0, len(s)+len(m))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	for key := range m {
+		if _, ok := s[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedMapKeys returns the keys of the map v sorted by their formatted value, so that the elements
+// of maps are visited, and their errors reported, in a stable order.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
+
+// ordered returns errs in the error order of the instance, see ErrorOrder.
+func (vd *Validate) ordered(errs Errors) Errors {
+	if vd.errorOrder != PathOrder {
+		return errs
+	}
+	flat := Errors(flattenInto(nil, errs))
+	segments := make([][]string, len(flat))
+	for i, err := range flat {
+		segments[i] = pathSegments(err)
+	}
+	sort.Stable(byPath{flat, segments})
+	return flat
+}
+
+// pathSegments returns the segments of the dotted path of err, nil for errors which are not an Error.
+func pathSegments(err error) []string {
+	switch e := err.(type) {
+	case Error:
+		return e.segments()
+	case *Error:
+		return e.segments()
+	}
+	return nil
+}
+
+// byPath sorts errors by the segments of their paths.
+type byPath struct {
+	errs     Errors
+	segments [][]string
+}
+
+func (p byPath) Len() int {
+	return len(p.errs)
+}
+
+func (p byPath) Less(i, j int) bool {
+	return comparePaths(p.segments[i], p.segments[j]) < 0
+}
+
+func (p byPath) Swap(i, j int) {
+	p.errs[i], p.errs[j] = p.errs[j], p.errs[i]
+	p.segments[i], p.segments[j] = p.segments[j], p.segments[i]
+}
+
+// comparePaths compares paths segment by segment, comparing numeric segments like slice indexes as numbers.
+func comparePaths(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		x, errX := strconv.Atoi(a[i])
+		y, errY := strconv.Atoi(b[i])
+		if errX == nil && errY == nil {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a[i], b[i])
+	}
+	return len(a) - len(b)
+}
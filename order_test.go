@@ -0,0 +1,76 @@
+package govalidator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrorOrder(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Zip  string `valid:"numeric"`
+		City string `valid:"required"`
+	}
+	type item struct {
+		SKU string `valid:"required"`
+	}
+	type order struct {
+		Name    string `valid:"required"`
+		Items   []item `valid:"dive"`
+		Address address
+		Email   string `valid:"email"`
+	}
+	items := make([]item, 11)
+	for i := range items {
+		items[i].SKU = "x"
+	}
+	items[2].SKU, items[10].SKU = "", ""
+	param := order{Items: items, Address: address{Zip: "x"}, Email: "x"}
+
+	var tests = []struct {
+		order    ErrorOrder
+		expected []string
+	}{
+		{DeclarationOrder, []string{"Name", "Items.2.SKU", "Items.10.SKU", "Address.Zip", "Address.City", "Email"}},
+		{PathOrder, []string{"Address.City", "Address.Zip", "Email", "Items.2.SKU", "Items.10.SKU", "Name"}},
+	}
+	for _, test := range tests {
+		vd := New(WithErrorOrder(test.order), WithFieldNameFunc(func(f reflect.StructField) string { return f.Name }))
+		for run := 0; run < 5; run++ {
+			_, err := vd.ValidateStruct(param)
+			var paths []string
+			for _, e := range Flatten(err) {
+				paths = append(paths, strings.Join(append(e.Path, e.Name), "."))
+			}
+			if strings.Join(paths, ",") != strings.Join(test.expected, ",") {
+				t.Fatalf("order %d: expected %v, got %v", test.order, test.expected, paths)
+			}
+		}
+	}
+}
+
+func TestValidateMapErrorOrder(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]interface{}{
+		"name":    "required",
+		"email":   "email",
+		"age":     "int",
+		"address": map[string]interface{}{"zip": "numeric", "city": "required"},
+	}
+	param := map[string]interface{}{
+		"email":   "x",
+		"age":     "x",
+		"address": map[string]interface{}{"zip": "x"},
+	}
+	expected := "address.city: required field missing;address.zip: x does not validate as numeric;" +
+		"age: x does not validate as int;email: x does not validate as email;name: required field missing"
+	for run := 0; run < 10; run++ {
+		_, err := ValidateMap(param, schema)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("expected %q, got %v", expected, err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package govalidator
+
+import (
+	"context"
+	"strings"
+)
+
+// pathFilter restricts validation to, or excludes from validation, the fields at a set of dotted paths.
+// Every node holds the segments following its own, a path like "Addresses.*.City" matches the City field
+// of every element of Addresses.
+type pathFilter struct {
+	except   bool
+	leaf     bool
+	children map[string]*pathFilter
+}
+
+// wildcardSegment matches every element of a slice or array and every key of a map.
+const wildcardSegment = "*"
+
+func newPathFilter(except bool, paths []string) *pathFilter {
+	root := &pathFilter{except: except}
+	for _, path := range paths {
+		node := root
+		for _, name := range strings.Split(path, ".") {
+			if node.children == nil {
+				node.children = make(map[string]*pathFilter)
+			}
+			child, ok := node.children[name]
+			if !ok {
+				child = &pathFilter{except: except}
+				node.children[name] = child
+			}
+			node = child
+		}
+		node.leaf = true
+	}
+	return root
+}
+
+// field tells whether the field (or element) name is validated at all, whether its own rules are applied
+// and which filter applies to its fields or elements, nil when all of them are validated.
+func (pf *pathFilter) field(name string) (sub *pathFilter, own, ok bool) {
+	if pf == nil {
+		return nil, true, true
+	}
+	node, found := pf.children[name]
+	if !found {
+		node, found = pf.children[wildcardSegment]
+	}
+	switch {
+	case !found:
+		return nil, pf.except, pf.except
+	case node.leaf:
+		return nil, !pf.except, !pf.except
+	}
+	// the field lies on a listed path: its fields or elements are filtered, and its own rules
+	// are applied unless only the fields below it were asked for
+	return node, pf.except, true
+}
+
+type pathFilterKey struct{}
+
+func pathFilterFrom(ctx context.Context) *pathFilter {
+	pf, _ := ctx.Value(pathFilterKey{}).(*pathFilter)
+	return pf
+}
+
+// filterContext returns the context validating the fields or elements of a value with the filter sub.
+func filterContext(ctx context.Context, pf, sub *pathFilter) context.Context {
+	if pf == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, pathFilterKey{}, sub)
+}
+
+// ValidateStructPartial validates only the fields of s at the given dotted paths, e.g. "Name" or
+// "Address.City", which is useful for PATCH requests. Elements of slices, arrays and maps are selected by
+// their index or key, or by "*" for all of them, e.g. "Addresses.0.Zip" or "Addresses.*.Zip".
+// Fields which are not listed are not validated, in particular they are not required.
+func ValidateStructPartial[T any](s T, fields ...string) (bool, error) {
+	return defaultValidate.ValidateStructPartial(s, fields...)
+}
+
+// ValidateStructPartial is the instance counterpart of the package level ValidateStructPartial.
+func (vd *Validate) ValidateStructPartial(s any, fields ...string) (bool, error) {
+	ctx := context.WithValue(context.Background(), pathFilterKey{}, newPathFilter(false, fields))
+	return vd.validateStruct(ctx, s)
+}
+
+// ValidateStructExcept validates every field of s except the ones at the given dotted paths,
+// see ValidateStructPartial for the syntax of the paths.
+func ValidateStructExcept[T any](s T, fields ...string) (bool, error) {
+	return defaultValidate.ValidateStructExcept(s, fields...)
+}
+
+// ValidateStructExcept is the instance counterpart of the package level ValidateStructExcept.
+func (vd *Validate) ValidateStructExcept(s any, fields ...string) (bool, error) {
+	ctx := context.WithValue(context.Background(), pathFilterKey{}, newPathFilter(true, fields))
+	return vd.validateStruct(ctx, s)
+}
@@ -0,0 +1,103 @@
+package govalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateStructPartial(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Street string `valid:"required"`
+		City   string `valid:"required,alpha"`
+	}
+	type user struct {
+		Name      string             `valid:"required,alpha"`
+		Email     string             `valid:"required,email"`
+		Address   address            `valid:"required"`
+		Addresses []address          `valid:"dive"`
+		Labels    map[string]address `valid:"-"`
+		Tags      []string           `valid:"dive,alpha"`
+	}
+
+	param := user{
+		Name:      "John",
+		Address:   address{City: "Paris1"},
+		Addresses: []address{{Street: "Main", City: "Rome"}, {City: "Oslo2"}},
+		Tags:      []string{"ok", "no1"},
+	}
+
+	var tests = []struct {
+		fields   []string
+		expected []string
+	}{
+		{[]string{"Name"}, nil},
+		{[]string{"Name", "Email"}, []string{"Email: non zero value required"}},
+		{[]string{"Address.City"}, []string{"Address.City: Paris1 does not validate as alpha"}},
+		{[]string{"Address"}, []string{"Address.Street: non zero value required", "Address.City: Paris1 does not validate as alpha"}},
+		{[]string{"Addresses.0"}, nil},
+		{[]string{"Addresses.1.City"}, []string{"Addresses.1.City: Oslo2 does not validate as alpha"}},
+		{[]string{"Addresses.*.Street"}, []string{"Addresses.1.Street: non zero value required"}},
+		{[]string{"Tags.0"}, nil},
+		{[]string{"Tags.1"}, []string{"Tags.1: no1 does not validate as alpha"}},
+	}
+	for _, test := range tests {
+		ok, err := ValidateStructPartial(param, test.fields...)
+		checkFilteredErrors(t, "partial", test.fields, ok, err, test.expected)
+	}
+}
+
+func TestValidateStructExcept(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Street string `valid:"required"`
+		City   string `valid:"required,alpha"`
+	}
+	type user struct {
+		Name      string    `valid:"required,alpha"`
+		Email     string    `valid:"required,email"`
+		Address   address   `valid:"required"`
+		Addresses []address `valid:"dive"`
+	}
+
+	param := user{
+		Name:      "John",
+		Email:     "john@example.com",
+		Address:   address{Street: "Main", City: "Paris1"},
+		Addresses: []address{{Street: "Main", City: "Rome"}, {City: "Oslo2"}},
+	}
+
+	var tests = []struct {
+		fields   []string
+		expected []string
+	}{
+		{[]string{"Address", "Addresses"}, nil},
+		{[]string{"Address.City", "Addresses.1"}, nil},
+		{[]string{"Address.City", "Addresses.*.City"}, []string{"Addresses.1.Street: non zero value required"}},
+		{[]string{"Addresses"}, []string{"Address.City: Paris1 does not validate as alpha"}},
+	}
+	for _, test := range tests {
+		ok, err := ValidateStructExcept(param, test.fields...)
+		checkFilteredErrors(t, "except", test.fields, ok, err, test.expected)
+	}
+}
+
+func checkFilteredErrors(t *testing.T, name string, fields []string, ok bool, err error, expected []string) {
+	t.Helper()
+	if ok != (expected == nil) {
+		t.Errorf("%s %v: expected %t, got %t: %v", name, fields, expected == nil, ok, err)
+		return
+	}
+	if expected == nil {
+		return
+	}
+	var messages []string
+	for _, e := range flattenErrors(err) {
+		messages = append(messages, e.Error())
+	}
+	if strings.Join(messages, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("%s %v: expected errors\n%s\ngot\n%s", name, fields, strings.Join(expected, "\n"), strings.Join(messages, "\n"))
+	}
+}
@@ -0,0 +1,351 @@
+package govalidator
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+type ruleKind int
+
+const (
+	ruleUnknown ruleKind = iota
+	ruleRequired
+	ruleOptional
+	ruleCustom
+	ruleField
+	ruleRequiredIf
+	ruleExcludedIf
+	ruleInterfaceParam
+	ruleParam
+	ruleTag
+	ruleNumber
+	ruleCollection
+	ruleTime
+	ruleDefault
+	ruleExpr
+)
+
+// rule is a single option of a tag resolved against the registries of a Validate.
+type rule struct {
+	// spec is the option as written in the tag, without the custom error message, e.g. "!length(1|10)"
+	spec string
+	// validator is spec without the negation, e.g. "length(1|10)"
+	validator string
+	// name is the name reported in Error.Validator, e.g. "length"
+	name    string
+	negate  bool
+	message string
+	kind    ruleKind
+	// params are handed to the validator, tagParams are the parameters as written in the tag, which
+	// are reported in Error.Param and differ for validators matching a tag without parentheses
+	params    []string
+	tagParams []string
+	// code is reported in Error.Code, it is kept when the rule belongs to an alias
+	code string
+
+	// key is the name of the validator of tag, param and interface param rules in the registries,
+	// their functions are looked up when they are applied, see tagFunc
+	key string
+	// rx is the regular expression param and interface param rules were matched with, see resolved
+	rx          *regexp.Regexp
+	customFn    CustomTypeValidatorCtx[any]
+	fieldFn     func(cmp int) bool
+	conditionFn func(o reflect.Value, params []string) bool
+	// numberFn, when set, is applied to int, uint and float values instead of paramFn
+	numberFn     func(v reflect.Value, params []numberParam) bool
+	numberParams []numberParam
+
+	collectionFn    func(v reflect.Value, n int) bool
+	collectionParam int
+
+	// timeFn applies to time.Time values, durationFn to time.Duration values
+	timeFn     func(t, now time.Time, p timeParam) bool
+	durationFn func(d time.Duration, p timeParam) bool
+	timeParam  timeParam
+
+	// expr is the expression tree of a rule like `(uuid|ulid)`
+	expr *exprNode
+}
+
+// tagPlan is a tag parsed and resolved once and shared by every field using the same tag.
+type tagPlan struct {
+	tag      string
+	rules    []*rule
+	required *rule
+	// defaults is the default(...) rule, setting zero fields of structs passed by pointer
+	defaults *rule
+	optional bool
+	// conditional is set when the tag contains required_* rules that depend on other fields
+	conditional bool
+	// dive holds the rules following a dive marker, which apply to each element of a collection,
+	// and keys the rules between keys and endkeys, which apply to each key of a map
+	dive *tagPlan
+	keys *tagPlan
+	// err reports a malformed tag, see ParseTag
+	err error
+}
+
+// fieldPlan describes how a single exported struct field (or a ValidateMap key) is validated.
+type fieldPlan struct {
+	index int
+	name  string
+	// errorName replaces name in the errors of the field, empty if name is kept, and pathName is
+	// the segment of the field in the paths of the errors of a nested struct
+	errorName string
+	pathName  string
+	// sanitize is the sanitize tag of the field
+	sanitize string
+	*tagPlan
+}
+
+// structPlan is the cached validation plan of a struct type.
+type structPlan struct {
+	fields []*fieldPlan
+}
+
+// planCache holds the plans built by a Validate. It is replaced as a whole when a validator is registered.
+// Plans are built for a set of active validation groups, only the rules of these groups and the
+// ungrouped rules are part of them.
+type planCache struct {
+	customGeneration uint64
+	structs          sync.Map // planKey -> *structPlan
+	tags             sync.Map // planKey -> *tagPlan
+	sanitizers       sync.Map // string -> *sanitizePlan
+}
+
+type planKey struct {
+	// typ is set for struct plans, tag for tag plans
+	typ    reflect.Type
+	tag    string
+	groups string
+}
+
+func (vd *Validate) cache() *planCache {
+	gen := vd.customTypeTagMap.generation()
+	if c := vd.plans.Load(); c != nil && c.customGeneration == gen {
+		return c
+	}
+	c := &planCache{customGeneration: gen}
+	vd.plans.Store(c)
+	return c
+}
+
+// resetPlans drops every cached plan, so that they are rebuilt with the current registries.
+func (vd *Validate) resetPlans() {
+	vd.plans.Store(nil)
+}
+
+func (vd *Validate) structPlanFor(t reflect.Type, groups string) *structPlan {
+	c := vd.cache()
+	key := planKey{typ: t, groups: groups}
+	if p, ok := c.structs.Load(key); ok {
+		return p.(*structPlan)
+	}
+	sp := &structPlan{}
+	for i := 0; i < t.NumField(); i++ {
+		typeField := t.Field(i)
+		if typeField.PkgPath != "" {
+			continue // Private field
+		}
+		fp := &fieldPlan{
+			index:    i,
+			name:     typeField.Name,
+			pathName: typeField.Name,
+			sanitize: typeField.Tag.Get(sanitizeTagName),
+			tagPlan:  vd.tagPlanFor(c, typeField.Tag.Get(vd.tagName), groups),
+		}
+		if vd.fieldNameFunc == nil {
+			fp.errorName = toJSONName(typeField.Tag.Get("json"))
+		} else {
+			fp.errorName = vd.fieldNameFunc(typeField)
+		}
+		if fp.errorName != "" {
+			fp.pathName = fp.errorName
+		}
+		sp.fields = append(sp.fields, fp)
+	}
+	p, _ := c.structs.LoadOrStore(key, sp)
+	return p.(*structPlan)
+}
+
+func (vd *Validate) tagPlanFor(c *planCache, tag, groups string) *tagPlan {
+	key := planKey{tag: tag, groups: groups}
+	if p, ok := c.tags.Load(key); ok {
+		return p.(*tagPlan)
+	}
+	tp := &tagPlan{tag: tag}
+	if tag != "" && tag != "-" {
+		own, keys, elem, hasDive := splitDive(tag)
+		if hasDive {
+			tp.dive = vd.tagPlanFor(c, elem, groups)
+			if keys != "" {
+				tp.keys = vd.tagPlanFor(c, keys, groups)
+			}
+		}
+		parsed, err := ParseTag(own)
+		tp.err = err
+		for _, tr := range parsed {
+			if !groupsActive(tr.Groups, groups) {
+				continue
+			}
+			rules := []*rule{vd.resolveRule(tr)}
+			if body, ok := vd.lookupAlias(tr.Rule); ok {
+				if expanded, ok := vd.expandAlias(body, tr.Message, groups, []string{tr.Rule}); ok {
+					rules = expanded
+				}
+				// the rules of an alias report errors under the name of the alias
+				for _, r := range rules {
+					r.name = tr.Rule
+				}
+			}
+			for _, r := range rules {
+				switch r.kind {
+				case ruleRequired:
+					tp.required = r
+				case ruleOptional:
+					tp.optional = true
+				case ruleDefault:
+					tp.defaults = r
+				case ruleRequiredIf:
+					tp.conditional = true
+				}
+				tp.rules = append(tp.rules, r)
+			}
+		}
+		// malformed element and key rules are reported for the collection itself
+		for _, sub := range []*tagPlan{tp.dive, tp.keys} {
+			if tp.err == nil && sub != nil {
+				tp.err = sub.err
+			}
+		}
+	}
+	p, _ := c.tags.LoadOrStore(key, tp)
+	return p.(*tagPlan)
+}
+
+// resolveRule looks the validator of a rule parsed by ParseTag up in the registries,
+// custom type validators take precedence over interface param, param and plain validators.
+func (vd *Validate) resolveRule(tr TagRule) *rule {
+	spec := tr.Rule
+	r := &rule{spec: spec, validator: spec, name: stripParams(spec), message: tr.Message, tagParams: tr.Params}
+	r.code = strings.TrimPrefix(r.name, "!")
+	if r.code != r.name {
+		r.code = "not_" + r.code
+	}
+	switch spec {
+	case "required":
+		r.kind = ruleRequired
+		return r
+	case "optional":
+		r.kind = ruleOptional
+		return r
+	}
+	if isExpression(spec) {
+		// malformed expressions are left unknown and reported as invalid validators
+		if n, err := vd.parseExpression(spec); err == nil {
+			r.kind, r.expr, r.name, r.code = ruleExpr, n, spec, CodeExpression
+		}
+		return r
+	}
+	if ps := rxDefaultTag.FindStringSubmatch(spec); len(ps) != 0 {
+		r.kind, r.params = ruleDefault, ps[1:]
+		return r
+	}
+	if fn, ok := vd.customTypeTagMap.GetCtx(spec); ok {
+		r.kind, r.customFn = ruleCustom, fn
+		return r
+	}
+	// checks whether the tag looks like '!something' or 'something'
+	if spec[0] == '!' {
+		r.validator = spec[1:]
+		r.negate = true
+	}
+	if fn, name, ps, ok := matchConditionTag(spec); ok {
+		r.kind, r.name, r.conditionFn, r.params = ruleRequiredIf, name, fn, ps
+		if name == "excluded_if" {
+			r.kind = ruleExcludedIf
+		}
+	} else if fn, path, ok := matchFieldTag(r.validator); ok {
+		r.kind, r.fieldFn, r.params = ruleField, fn, []string{path}
+	} else if key, rx, ps, ok := vd.matchInterfaceParamTag(r.validator); ok {
+		r.kind, r.key, r.rx, r.params = ruleInterfaceParam, key, rx, ps
+	} else if key, rx, ps, ok := vd.matchParamTag(r.validator); ok {
+		r.kind, r.key, r.rx, r.params = ruleParam, key, rx, validatorParams(key, tr, ps)
+		// a param validator named like a numeric tag, e.g. range, compares numbers natively
+		if fn, _, nps, ok := matchNumberTag(r.validator); ok {
+			r.numberFn, r.numberParams = fn, nps
+		}
+	} else if _, ok := vd.lookupTag(r.validator); ok {
+		r.kind, r.key = ruleTag, r.validator
+	} else if fn, ps, nps, ok := matchNumberTag(r.validator); ok {
+		r.kind, r.numberFn, r.params, r.numberParams = ruleNumber, fn, ps, nps
+	} else if fn, ps, n, ok := matchCollectionTag(r.validator); ok {
+		r.kind, r.collectionFn, r.params, r.collectionParam = ruleCollection, fn, ps, n
+	} else if timeFn, durationFn, ps, p, ok := matchTimeTag(r.validator); ok {
+		r.kind, r.timeFn, r.durationFn, r.params, r.timeParam = ruleTime, timeFn, durationFn, ps, p
+	}
+	return r
+}
+
+// parsedParamTags are the param validators handed the parameters parsed from the tag, so that quoted
+// parameters may hold | and ). Other param validators receive the submatches of their regular expression.
+var parsedParamTags = map[string]bool{"in": true, "matches": true}
+
+// validatorParams returns the parameters handed to the param validator registered under key for tr.
+func validatorParams(key string, tr TagRule, submatches []string) []string {
+	if tr.Params != nil && parsedParamTags[key] {
+		return tr.Params
+	}
+	return submatches
+}
+
+// tagFunc, paramFunc and interfaceFunc return the function currently registered for a rule. They are not
+// kept in the plans, because validators may be replaced in the package level maps directly at any time.
+func (vd *Validate) tagFunc(r *rule) (Validator[string], bool) {
+	return vd.lookupTag(r.key)
+}
+
+func (vd *Validate) paramFunc(r *rule) (ParamValidator[string], bool) {
+	vd.mu.RLock()
+	defer vd.mu.RUnlock()
+	fn, ok := vd.paramTagMap[r.key]
+	return fn, ok
+}
+
+func (vd *Validate) interfaceFunc(r *rule) (InterfaceParamValidator[any], bool) {
+	vd.mu.RLock()
+	defer vd.mu.RUnlock()
+	fn, ok := vd.interfaceParamTagMap[r.key]
+	return fn, ok
+}
+
+// resolved returns the rule itself, or a rule resolved against the current registries for a rule that
+// was unknown when the plan was built, or whose regular expression was replaced since. Validators may
+// still be added to, or replaced in, the package level maps directly, after a plan using them was cached.
+// The cached plans are dropped when a regular expression was replaced, so that they are rebuilt.
+func (vd *Validate) resolved(r *rule) *rule {
+	switch r.kind {
+	case ruleUnknown:
+	case ruleParam, ruleInterfaceParam:
+		if vd.paramRegex(r) == r.rx {
+			return r
+		}
+		vd.resetPlans()
+	default:
+		return r
+	}
+	return vd.resolveRule(TagRule{Rule: r.spec, Message: r.message, Params: r.tagParams})
+}
+
+// paramRegex returns the regular expression currently registered for a param or interface param rule.
+func (vd *Validate) paramRegex(r *rule) *regexp.Regexp {
+	vd.mu.RLock()
+	defer vd.mu.RUnlock()
+	if r.kind == ruleInterfaceParam {
+		return vd.interfaceParamTagRegexMap[r.key]
+	}
+	return vd.paramTagRegexMap[r.key]
+}
@@ -0,0 +1,152 @@
+package govalidator
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestStructPlanIsCached(t *testing.T) {
+	t.Parallel()
+
+	type planned struct {
+		Email string `valid:"email,required"`
+		Name  string `valid:"alpha"`
+		Other string `valid:"email,required"`
+	}
+
+	vd := New()
+	typ := reflect.TypeOf(planned{})
+	p := vd.structPlanFor(typ, "")
+	if p != vd.structPlanFor(typ, "") {
+		t.Error("Expected the plan of a struct type to be cached")
+	}
+	if len(p.fields) != 3 {
+		t.Fatalf("Expected 3 fields in the plan, got %d", len(p.fields))
+	}
+	if p.fields[0].tagPlan != p.fields[2].tagPlan {
+		t.Error("Expected fields with the same tag to share a tag plan")
+	}
+	if p.fields[0].required == nil || p.fields[1].required != nil {
+		t.Error("Expected required to be resolved in the tag plan")
+	}
+	if r := p.fields[1].rules[0]; r.kind != ruleTag || r.key != "alpha" {
+		t.Errorf("Expected alpha to be resolved to a TagMap validator, got kind %v", r.kind)
+	}
+}
+
+func TestRegisterInvalidatesPlans(t *testing.T) {
+	t.Parallel()
+
+	type planned struct {
+		Code string `valid:"lateValidator"`
+	}
+
+	vd := New()
+	if ok, _ := vd.ValidateStruct(planned{Code: "x"}); ok {
+		t.Error("Expected unknown validator to fail")
+	}
+	vd.RegisterTag("lateValidator", func(str string) bool {
+		return str == "x"
+	})
+	if ok, err := vd.ValidateStruct(planned{Code: "x"}); !ok || err != nil {
+		t.Errorf("Expected registered validator to be used, got %v %v", ok, err)
+	}
+	vd.RegisterTag("lateValidator", func(str string) bool {
+		return str == "y"
+	})
+	if ok, _ := vd.ValidateStruct(planned{Code: "x"}); ok {
+		t.Error("Expected the replaced validator to be used")
+	}
+	vd.RegisterCustomTypeTag("lateValidator", func(i any, o any) bool {
+		return true
+	})
+	if ok, err := vd.ValidateStruct(planned{Code: "x"}); !ok || err != nil {
+		t.Errorf("Expected the custom type validator to take precedence, got %v %v", ok, err)
+	}
+}
+
+func TestPackageLevelMapsAfterPlanIsCached(t *testing.T) {
+	type planned struct {
+		Code string `valid:"lateGlobalValidator"`
+	}
+
+	if ok, _ := ValidateStruct(planned{Code: "x"}); ok {
+		t.Error("Expected unknown validator to fail")
+	}
+	TagMap["lateGlobalValidator"] = func(str string) bool {
+		return str == "x"
+	}
+	defer delete(TagMap, "lateGlobalValidator")
+	if ok, err := ValidateStruct(planned{Code: "x"}); !ok || err != nil {
+		t.Errorf("Expected validator added to TagMap to be used, got %v %v", ok, err)
+	}
+}
+
+func TestPackageLevelMapsReassignedAfterPlanIsCached(t *testing.T) {
+	type planned struct {
+		Code  string `valid:"reassignedValidator"`
+		Range string `valid:"reassignedParam(3)"`
+	}
+
+	TagMap["reassignedValidator"] = func(str string) bool {
+		return str == "x"
+	}
+	ParamTagMap["reassignedParam"] = func(str string, params ...string) bool {
+		return len(str) < 3
+	}
+	ParamTagRegexMap["reassignedParam"] = regexp.MustCompile(`^reassignedParam\((\d+)\)$`)
+	defer func() {
+		delete(TagMap, "reassignedValidator")
+		delete(ParamTagMap, "reassignedParam")
+		delete(ParamTagRegexMap, "reassignedParam")
+	}()
+	if ok, err := ValidateStruct(planned{Code: "x", Range: "ab"}); !ok || err != nil {
+		t.Fatalf("Expected the registered validators to pass, got %v %v", ok, err)
+	}
+	TagMap["reassignedValidator"] = func(str string) bool {
+		return str == "y"
+	}
+	ParamTagMap["reassignedParam"] = func(str string, params ...string) bool {
+		return len(str) >= 3
+	}
+	_, err := ValidateStruct(planned{Code: "x", Range: "ab"})
+	if len(flattenErrors(err)) != 2 {
+		t.Errorf("Expected the reassigned validators to be used, got %v", err)
+	}
+	delete(TagMap, "reassignedValidator")
+	if ok, _ := ValidateStruct(planned{Code: "y", Range: "abc"}); ok {
+		t.Error("Expected a removed validator to be reported as invalid")
+	}
+}
+
+func TestParamTagRegexReassignedAfterPlanIsCached(t *testing.T) {
+	type planned struct {
+		Code string `valid:"reassignedRegex(3-5)"`
+	}
+
+	var got []string
+	ParamTagMap["reassignedRegex"] = func(str string, params ...string) bool {
+		got = params
+		return true
+	}
+	ParamTagRegexMap["reassignedRegex"] = regexp.MustCompile(`^reassignedRegex\((.*)\)$`)
+	defer func() {
+		delete(ParamTagMap, "reassignedRegex")
+		delete(ParamTagRegexMap, "reassignedRegex")
+	}()
+	if ok, err := ValidateStruct(planned{"x"}); !ok || strings.Join(got, ",") != "3-5" {
+		t.Fatalf("Expected the validator to get the submatch 3-5, got %v %v", got, err)
+	}
+	ParamTagRegexMap["reassignedRegex"] = regexp.MustCompile(`^reassignedRegex\((\d+)-(\d+)\)$`)
+	for i := 0; i < 2; i++ {
+		if ok, err := ValidateStruct(planned{"x"}); !ok || strings.Join(got, ",") != "3,5" {
+			t.Errorf("Expected the reassigned regular expression to be used, got %v %v", got, err)
+		}
+	}
+	ParamTagRegexMap["reassignedRegex"] = regexp.MustCompile(`^reassignedRegex\((\d+)\)$`)
+	if ok, _ := ValidateStruct(planned{"x"}); ok {
+		t.Error("Expected a rule no longer matching the regular expression to be reported as invalid")
+	}
+}
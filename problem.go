@@ -0,0 +1,54 @@
+package govalidator
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details documents.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem details document reporting failed validation,
+// its InvalidParams extension lists the errors of every invalid field.
+type ProblemDetails struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params"`
+}
+
+// InvalidParam is an entry of the invalid-params extension of a ProblemDetails. Name is the path of the
+// field as a JSON Pointer and Reason the message of the error, the other members are those of Error.
+type InvalidParam struct {
+	Name      string   `json:"name"`
+	Reason    string   `json:"reason"`
+	Validator string   `json:"validator,omitempty"`
+	Code      string   `json:"code,omitempty"`
+	Params    []string `json:"params,omitempty"`
+}
+
+// NewProblemDetails wraps the Error or Errors returned by ValidateStruct or ValidateMap into a problem
+// details document with status 400 Bad Request, to be encoded as JSON and sent with ProblemContentType:
+//
+//	w.Header().Set("Content-Type", govalidator.ProblemContentType)
+//	w.WriteHeader(problem.Status)
+//	json.NewEncoder(w).Encode(problem)
+func NewProblemDetails(err error) *ProblemDetails {
+	p := &ProblemDetails{
+		Type:          "about:blank",
+		Title:         "Your request parameters didn't validate.",
+		Status:        http.StatusBadRequest,
+		InvalidParams: []InvalidParam{},
+	}
+	for _, err := range flattenInto(nil, err) {
+		var e Error
+		if !errors.As(err, &e) {
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{Reason: err.Error()})
+			continue
+		}
+		p.InvalidParams = append(p.InvalidParams, InvalidParam{e.Pointer(), e.message(), e.Validator, e.Code, e.Param})
+	}
+	return p
+}
@@ -0,0 +1,30 @@
+package govalidator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewProblemDetails(t *testing.T) {
+	t.Parallel()
+
+	type signup struct {
+		Email string `json:"email" valid:"required,email"`
+		Age   int    `json:"age" valid:"range(18|130)"`
+	}
+	_, err := ValidateStruct(signup{Age: 7})
+	actual, mErr := json.Marshal(NewProblemDetails(err))
+	if mErr != nil {
+		t.Fatal(mErr)
+	}
+	expected := `{"type":"about:blank","title":"Your request parameters didn't validate.","status":400,"invalid-params":[` +
+		`{"name":"/email","reason":"non zero value required","validator":"required","code":"required"},` +
+		`{"name":"/age","reason":"7 does not validate as range(18|130)","validator":"range","code":"range","params":["18","130"]}]}`
+	if string(actual) != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+
+	if p := NewProblemDetails(nil); len(p.InvalidParams) != 0 {
+		t.Errorf("expected no invalid params, got %v", p.InvalidParams)
+	}
+}
@@ -0,0 +1,237 @@
+package govalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Sanitizer is a wrapper for a function normalizing a string, used with the sanitize tag.
+type Sanitizer func(str string) string
+
+// ParamSanitizer is a wrapper for sanitizer functions that accept additional parameters.
+type ParamSanitizer func(str string, params ...string) string
+
+// sanitizeTagName is the struct tag holding the sanitizers of a field, e.g. `sanitize:"trim,lower"`.
+const sanitizeTagName = "sanitize"
+
+// SanitizerMap is a map of functions that can be used as sanitizers in the sanitize tag.
+var SanitizerMap = map[string]Sanitizer{
+	"trim":     func(str string) string { return Trim(str, "") },
+	"ltrim":    func(str string) string { return LeftTrim(str, "") },
+	"rtrim":    func(str string) string { return RightTrim(str, "") },
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+	"striplow": func(str string) string { return StripLow(str, false) },
+	"striplow_keepnewlines": func(str string) string {
+		return StripLow(str, true)
+	},
+	"remove_tags":   RemoveTags[string],
+	"safe_filename": SafeFileName[string],
+	"normalize_email": func(str string) string {
+		// addresses which are not valid are left alone, so that validation reports them
+		if email, err := NormalizeEmail(str); err == nil {
+			return email
+		}
+		return str
+	},
+}
+
+// ParamSanitizerMap is a map of sanitizers with parameters, e.g. `sanitize:"whitelist(a-z0-9)"`.
+// Several parameters are separated by |.
+var ParamSanitizerMap = map[string]ParamSanitizer{
+	"trim":  func(str string, params ...string) string { return Trim(str, params[0]) },
+	"ltrim": func(str string, params ...string) string { return LeftTrim(str, params[0]) },
+	"rtrim": func(str string, params ...string) string { return RightTrim(str, params[0]) },
+	"whitelist": func(str string, params ...string) string {
+		return WhiteList(str, params[0])
+	},
+	"blacklist": func(str string, params ...string) string {
+		return BlackList(str, params[0])
+	},
+	"replace": func(str string, params ...string) string {
+		if len(params) != 2 {
+			return str
+		}
+		return ReplacePattern(str, params[0], params[1])
+	},
+}
+
+// sanitizeStep is a single sanitizer of a sanitize tag.
+type sanitizeStep struct {
+	TagRule
+	fn      Sanitizer
+	paramFn ParamSanitizer
+}
+
+// sanitizePlan is a sanitize tag parsed once and shared by every field using it.
+type sanitizePlan struct {
+	steps []*sanitizeStep
+	// err reports a malformed tag, see ParseTag
+	err error
+}
+
+// RegisterSanitizer adds a sanitizer usable as `sanitize:"name"`.
+func (vd *Validate) RegisterSanitizer(name string, fn Sanitizer) {
+	vd.mu.Lock()
+	defer vd.mu.Unlock()
+	vd.sanitizerMap[name] = fn
+	vd.resetPlans()
+}
+
+// RegisterParamSanitizer adds a sanitizer with parameters usable as `sanitize:"name(a|b)"`.
+func (vd *Validate) RegisterParamSanitizer(name string, fn ParamSanitizer) {
+	vd.mu.Lock()
+	defer vd.mu.Unlock()
+	vd.paramSanitizerMap[name] = fn
+	vd.resetPlans()
+}
+
+func (vd *Validate) sanitizePlanFor(c *planCache, tag string) *sanitizePlan {
+	if p, ok := c.sanitizers.Load(tag); ok {
+		return p.(*sanitizePlan)
+	}
+	parsed, err := ParseTag(tag)
+	sp := &sanitizePlan{err: err}
+	for _, tr := range parsed {
+		sp.steps = append(sp.steps, vd.resolveSanitizer(tr))
+	}
+	p, _ := c.sanitizers.LoadOrStore(tag, sp)
+	return p.(*sanitizePlan)
+}
+
+// resolveSanitizer looks the sanitizer of a tag option up in the registries.
+// The functions of unknown sanitizers are left nil.
+func (vd *Validate) resolveSanitizer(tr TagRule) *sanitizeStep {
+	step := &sanitizeStep{TagRule: tr}
+	if tr.Name == "" || tr.Negated {
+		return step
+	}
+	vd.mu.RLock()
+	defer vd.mu.RUnlock()
+	if tr.Params != nil {
+		step.paramFn = vd.paramSanitizerMap[tr.Name]
+	} else {
+		step.fn = vd.sanitizerMap[tr.Name]
+	}
+	return step
+}
+
+// apply runs the sanitizers of the plan on str in tag order.
+func (vd *Validate) apply(sp *sanitizePlan, str string) (string, error) {
+	if sp.err != nil {
+		return str, sp.err
+	}
+	for _, step := range sp.steps {
+		if step.fn == nil && step.paramFn == nil {
+			// the sanitizer may have been added to the package level maps after the plan was built
+			step = vd.resolveSanitizer(step.TagRule)
+		}
+		switch {
+		case step.fn != nil:
+			str = step.fn(str)
+		case step.paramFn != nil:
+			str = step.paramFn(str, step.Params...)
+		default:
+			return str, fmt.Errorf("unknown sanitizer %q", step.Rule)
+		}
+	}
+	return str, nil
+}
+
+// SanitizeStruct normalizes the string fields of the struct s points to with the sanitizers of their
+// sanitize tag, in tag order, e.g. `sanitize:"trim,lower"`. Nested structs and the elements of slices,
+// arrays and maps are sanitized as well, the sanitizers of a slice or map of strings apply to each element.
+func SanitizeStruct(s any) error {
+	return defaultValidate.SanitizeStruct(s)
+}
+
+// SanitizeStruct is the instance counterpart of the package level SanitizeStruct.
+func (vd *Validate) SanitizeStruct(s any) error {
+	val := reflect.ValueOf(s)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("function only accepts pointers to structs; got %T", s)
+	}
+	return vd.sanitizeStruct(vd.cache(), val.Elem())
+}
+
+// SanitizeAndValidate sanitizes the struct s points to, see SanitizeStruct, and validates it afterwards.
+func SanitizeAndValidate(s any) (bool, error) {
+	return defaultValidate.SanitizeAndValidate(s)
+}
+
+// SanitizeAndValidate is the instance counterpart of the package level SanitizeAndValidate.
+func (vd *Validate) SanitizeAndValidate(s any) (bool, error) {
+	if err := vd.SanitizeStruct(s); err != nil {
+		return false, err
+	}
+	return vd.validateStruct(context.Background(), s)
+}
+
+func (vd *Validate) sanitizeStruct(c *planCache, v reflect.Value) error {
+	var errs Errors
+	for _, fp := range vd.structPlanFor(v.Type(), "").fields {
+		var sp *sanitizePlan
+		if fp.sanitize != "" {
+			sp = vd.sanitizePlanFor(c, fp.sanitize)
+		}
+		if err := vd.sanitizeValue(c, v.Field(fp.index), sp); err != nil {
+			errs = append(errs, prependPathToErrors(err, fp.pathName))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// sanitizeValue applies sp to the strings held by v, and the sanitize tags of the structs held by it.
+func (vd *Validate) sanitizeValue(c *planCache, v reflect.Value, sp *sanitizePlan) error {
+	switch v.Kind() {
+	case reflect.String:
+		if sp == nil || !v.CanSet() {
+			return nil
+		}
+		str, err := vd.apply(sp, v.String())
+		if err != nil {
+			return Error{Err: err, Validator: sanitizeTagName, Path: []string{}, Value: v.String(), Kind: v.Kind(), Code: CodeSanitize}
+		}
+		v.SetString(str)
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if e := v.Elem(); v.Kind() == reflect.Ptr || e.Kind() == reflect.Ptr {
+			return vd.sanitizeValue(c, e, sp)
+		}
+	case reflect.Struct:
+		return vd.sanitizeStruct(c, v)
+	case reflect.Slice, reflect.Array:
+		var errs Errors
+		for i := 0; i < v.Len(); i++ {
+			if err := vd.sanitizeValue(c, v.Index(i), sp); err != nil {
+				errs = append(errs, prependPathToErrors(err, fmt.Sprint(i)))
+			}
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+	case reflect.Map:
+		var errs Errors
+		for _, k := range sortedMapKeys(v) {
+			// map elements are not addressable, so a copy is sanitized and stored back
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(v.MapIndex(k))
+			if err := vd.sanitizeValue(c, e, sp); err != nil {
+				errs = append(errs, prependPathToErrors(err, fmt.Sprint(k)))
+				continue
+			}
+			v.SetMapIndex(k, e)
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package govalidator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeStruct(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		City string `sanitize:"trim,upper"`
+	}
+	type user struct {
+		Name      string            `sanitize:"trim,striplow"`
+		Email     string            `sanitize:"trim,normalize_email"`
+		Nick      *string           `sanitize:"lower"`
+		Phone     string            `sanitize:"whitelist(0-9+)"`
+		Slug      string            `sanitize:"lower,replace('[ ,]+'|'-')"`
+		Tags      []string          `sanitize:"trim,lower"`
+		Labels    map[string]string `sanitize:"rtrim"`
+		Address   address
+		Addresses []*address
+		Others    map[string]address
+		Raw       string
+	}
+
+	nick := "JohnD"
+	u := &user{
+		Name:      "  John\x00 Doe\n ",
+		Email:     " Some.One+tag@GoogleMail.com ",
+		Nick:      &nick,
+		Phone:     "+1 (555) 010-999",
+		Slug:      "Hello, World",
+		Tags:      []string{" Go ", "RUST"},
+		Labels:    map[string]string{"a": "x  "},
+		Address:   address{" paris "},
+		Addresses: []*address{{" rome"}, nil},
+		Others:    map[string]address{"home": {"oslo "}},
+		Raw:       "  untouched ",
+	}
+	if err := SanitizeStruct(u); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &user{
+		Name:      "John Doe",
+		Email:     "someone@gmail.com",
+		Nick:      &nick,
+		Phone:     "+1555010999",
+		Slug:      "hello-world",
+		Tags:      []string{"go", "rust"},
+		Labels:    map[string]string{"a": "x"},
+		Address:   address{"PARIS"},
+		Addresses: []*address{{"ROME"}, nil},
+		Others:    map[string]address{"home": {"OSLO"}},
+		Raw:       "  untouched ",
+	}
+	if !reflect.DeepEqual(u, expected) || nick != "johnd" {
+		t.Errorf("expected %+v, got %+v", expected, u)
+	}
+}
+
+func TestSanitizeStructErrors(t *testing.T) {
+	t.Parallel()
+
+	type unknown struct {
+		Name string `sanitize:"trim,shout"`
+	}
+	err := SanitizeStruct(&unknown{"x"})
+	if err == nil || err.Error() != `Name: unknown sanitizer "shout"` {
+		t.Errorf("expected unknown sanitizer error, got %v", err)
+	}
+	if e := err.(Errors)[0].(Error); e.Code != CodeSanitize {
+		t.Errorf("expected code %s, got %s", CodeSanitize, e.Code)
+	}
+	type labels struct {
+		Labels map[string]string `sanitize:"shout"`
+	}
+	for i := 0; i < 10; i++ {
+		err := SanitizeStruct(&labels{map[string]string{"c": "x", "a": "x", "b": "x"}})
+		expected := `Labels.a: unknown sanitizer "shout";Labels.b: unknown sanitizer "shout";Labels.c: unknown sanitizer "shout"`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("expected the errors of the map values in key order, got %v", err)
+		}
+	}
+	type malformed struct {
+		Name string `sanitize:"trim("`
+	}
+	if err := SanitizeStruct(&malformed{"x"}); err == nil || !strings.Contains(err.Error(), "malformed tag") {
+		t.Errorf("expected malformed tag error, got %v", err)
+	}
+	if err := SanitizeStruct(unknown{}); err == nil || !strings.Contains(err.Error(), "pointers to structs") {
+		t.Errorf("expected error for a struct passed by value, got %v", err)
+	}
+}
+
+func TestSanitizeAndValidate(t *testing.T) {
+	t.Parallel()
+
+	type signup struct {
+		Email string `sanitize:"trim,lower" valid:"email,required"`
+		Code  string `sanitize:"shout" valid:"alpha"`
+	}
+
+	vd := New()
+	vd.RegisterSanitizer("shout", strings.ToUpper)
+	s := &signup{Email: "  John@Example.COM ", Code: "abc"}
+	ok, err := vd.SanitizeAndValidate(s)
+	if !ok || err != nil {
+		t.Errorf("expected sanitized struct to be valid, got %v", err)
+	}
+	if s.Email != "john@example.com" || s.Code != "ABC" {
+		t.Errorf("unexpected sanitized values %+v", s)
+	}
+	if err := SanitizeStruct(&signup{Code: "abc"}); err == nil {
+		t.Error("expected sanitizers registered on an instance not to be visible to the package level functions")
+	}
+}
@@ -0,0 +1,262 @@
+package govalidator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TagRule is a single rule of a validation tag, as returned by ParseTag.
+type TagRule struct {
+	// Rule is the rule without quotes, custom message and groups, e.g. "!length(1|10)" or "matches(^[a-z]{1,3}$)"
+	Rule string
+	// Name is the name of the validator, e.g. "length", it is empty for boolean expressions like "(uuid|ulid)"
+	Name string
+	// Params are the parameters of the rule as parsed from the tag, e.g. ["1", "10"] for "length(1|10)"
+	// and ["a|b", "c"] for "in('a|b'|c)"
+	Params  []string
+	Negated bool
+	// Message is the custom error message following ~
+	Message string
+	// Groups are the validation groups following @
+	Groups []string
+}
+
+// TagSyntaxError reports a malformed tag, Offset is the byte offset of the problem in Tag.
+type TagSyntaxError struct {
+	Tag    string
+	Offset int
+	Msg    string
+}
+
+func (e *TagSyntaxError) Error() string {
+	return fmt.Sprintf("malformed tag %q at offset %d: %s", e.Tag, e.Offset, e.Msg)
+}
+
+// ParseTag parses a validation tag like `required~Name is required,length(1|10)@create` into its rules.
+//
+// Rules are separated by commas outside of parentheses, so `matches(^[a-z]{1,3}$)` is a single rule.
+// A parameter, or a custom message, may be quoted with ' or " to hold any character, e.g.
+// `in('a, b'|c)` or `required~'Please, enter a name'`; within quotes \' (or \") stands for the quote.
+// A message is quoted only when its closing quote ends the rule, otherwise its quotes are plain text,
+// e.g. `email~'%s' is not a valid email`.
+// Outside of quotes a backslash makes the following character literal: it does not separate rules, start
+// a message or count as a parenthesis. \, and \~ stand for , and ~, while other escapes are handed to
+// the validator unchanged, so that regular expressions like `matches(^\(\d+\)$)` keep working.
+// In messages every escaped character stands for itself.
+//
+// Malformed tags, e.g. with unbalanced parentheses or unterminated quotes, are reported as a
+// *TagSyntaxError along with the rules preceding the problem.
+func ParseTag(tag string) ([]TagRule, error) {
+	options, splitErr := splitOptions(tag)
+	rules := make([]TagRule, 0, len(options))
+	for _, o := range options {
+		r, err := parseOption(tag, o)
+		if err != nil {
+			return rules, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, splitErr
+}
+
+// tagOption is the text of a single option of a tag and its offset in the tag.
+type tagOption struct {
+	text   string
+	offset int
+}
+
+// splitOptions splits a tag at the commas separating its options. Empty options are skipped.
+func splitOptions(tag string) ([]tagOption, error) {
+	var options []tagOption
+	start, depth := 0, 0
+	// message is the offset of the custom message of the current option, -1 before its ~
+	message := -1
+	add := func(end int) {
+		text := strings.TrimLeft(tag[start:end], " ")
+		offset := end - len(text)
+		if text = strings.TrimRight(text, " "); text != "" {
+			options = append(options, tagOption{text, offset})
+		}
+	}
+	for i := 0; i < len(tag); i++ {
+		c := tag[i]
+		switch {
+		case c == '\\':
+			i++
+		case isQuote(c) && quoteStarts(tag, i, start, message, depth):
+			end, err := closingQuote(tag, i, message >= 0)
+			if err != nil {
+				if message >= 0 {
+					// the message is not quoted, e.g. `'%s' is invalid`, see quotedMessage
+					continue
+				}
+				return options, err
+			}
+			i = end
+		case c == ',' && depth == 0:
+			add(i)
+			start, message = i+1, -1
+		case message >= 0:
+			// parentheses are plain text in messages
+		case c == '~' && depth == 0:
+			message = i + 1
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth == 0 {
+				return options, &TagSyntaxError{tag, i, "unexpected )"}
+			}
+			depth--
+		}
+	}
+	if depth > 0 {
+		return options, &TagSyntaxError{tag, len(tag), "missing )"}
+	}
+	add(len(tag))
+	return options, nil
+}
+
+func isQuote(c byte) bool {
+	return c == '\'' || c == '"'
+}
+
+// quoteStarts tells whether the quote at i starts a quoted text: a quote is only special at the start
+// of a message or of a parameter, so that apostrophes elsewhere keep their meaning.
+func quoteStarts(tag string, i, start, message, depth int) bool {
+	if message >= 0 {
+		return strings.TrimSpace(tag[message:i]) == ""
+	}
+	if depth == 0 {
+		return false
+	}
+	before := strings.TrimRight(tag[start:i], " ")
+	return strings.HasSuffix(before, "(") || strings.HasSuffix(before, "|")
+}
+
+// closingQuote returns the offset of the quote closing the one at i, which has to be followed by the
+// end of the option for a message, or by | or ) for a parameter.
+func closingQuote(tag string, i int, message bool) (int, error) {
+	q := tag[i]
+	for j := i + 1; j < len(tag); j++ {
+		switch tag[j] {
+		case '\\':
+			j++
+		case q:
+			rest := strings.TrimLeft(tag[j+1:], " ")
+			next := len(tag) - len(rest)
+			switch {
+			case rest == "" || rest[0] == ',' && message:
+			case !message && (rest[0] == '|' || rest[0] == ')'):
+			default:
+				return 0, &TagSyntaxError{tag, next, fmt.Sprintf("unexpected %q after quoted text", rest[0])}
+			}
+			return j, nil
+		}
+	}
+	return 0, &TagSyntaxError{tag, i, "unterminated quote"}
+}
+
+// quotedMessage returns the text of a message enclosed in quotes. A message is quoted only when the quote
+// closing its first quote ends it, so that legacy messages like `'%s' is not a valid email` are kept as they are.
+func quotedMessage(message string) (string, bool) {
+	if message == "" || !isQuote(message[0]) {
+		return "", false
+	}
+	if end, err := closingQuote(message, 0, true); err != nil || end != len(message)-1 {
+		return "", false
+	}
+	return unquote(message[1:len(message)-1], message[0]), true
+}
+
+// unquote returns the text between two quotes q, in which \q stands for q.
+func unquote(s string, q byte) string {
+	return strings.ReplaceAll(s, `\`+string(q), string(q))
+}
+
+// unescapeMessage replaces every escaped character of a custom message by the character itself.
+func unescapeMessage(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// parseOption parses an option of tag as split by splitOptions, which checked its quotes and parentheses.
+func parseOption(tag string, o tagOption) (TagRule, error) {
+	var r TagRule
+	var spec strings.Builder
+	text := o.text
+	depth, paramStart := 0, -1
+	i := 0
+loop:
+	for ; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text):
+			i++
+			if text[i] != ',' && text[i] != '~' {
+				spec.WriteByte('\\')
+			}
+			spec.WriteByte(text[i])
+		case isQuote(c) && quoteStarts(text, i, 0, -1, depth):
+			end, _ := closingQuote(text, i, false)
+			spec.WriteString(unquote(text[i+1:end], c))
+			i = end
+		case c == '~' && depth == 0:
+			break loop
+		case c == '(':
+			depth++
+			spec.WriteByte(c)
+			if depth == 1 && r.Params == nil {
+				paramStart = spec.Len()
+			}
+		case c == ')':
+			depth--
+			if depth == 0 && paramStart >= 0 {
+				r.Params = append(r.Params, spec.String()[paramStart:])
+				paramStart = -1
+			}
+			spec.WriteByte(c)
+		case c == '|' && depth == 1 && paramStart >= 0:
+			r.Params = append(r.Params, spec.String()[paramStart:])
+			spec.WriteByte(c)
+			paramStart = spec.Len()
+		default:
+			spec.WriteByte(c)
+		}
+	}
+	if i < len(text) {
+		message := strings.TrimSpace(text[i+1:])
+		if quoted, ok := quotedMessage(message); ok {
+			r.Message = quoted
+		} else {
+			r.Message = unescapeMessage(message)
+		}
+	}
+
+	r.Rule, r.Groups = splitGroups(strings.TrimSpace(spec.String()))
+	if r.Rule == "" {
+		return r, &TagSyntaxError{tag, o.offset, "missing rule"}
+	}
+	if isExpression(r.Rule) {
+		r.Params = nil
+		return r, nil
+	}
+	name := r.Rule
+	if name[0] == '!' {
+		r.Negated = true
+		name = name[1:]
+	}
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = name[:i]
+	}
+	if !isValidTag(name) {
+		return r, &TagSyntaxError{tag, o.offset, fmt.Sprintf("invalid rule name %q", name)}
+	}
+	r.Name = name
+	return r, nil
+}
@@ -0,0 +1,169 @@
+package govalidator
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		tag      string
+		expected []TagRule
+	}{
+		{"", []TagRule{}},
+		{"required, email", []TagRule{{Rule: "required", Name: "required"}, {Rule: "email", Name: "email"}}},
+		{"length(1|10)", []TagRule{{Rule: "length(1|10)", Name: "length", Params: []string{"1", "10"}}}},
+		{"!in(a|b)", []TagRule{{Rule: "!in(a|b)", Name: "in", Params: []string{"a", "b"}, Negated: true}}},
+		{"matches(^[a-z]{1,3}$)", []TagRule{{Rule: "matches(^[a-z]{1,3}$)", Name: "matches", Params: []string{"^[a-z]{1,3}$"}}}},
+		{`matches('^(a|b), $')`, []TagRule{{Rule: "matches(^(a|b), $)", Name: "matches", Params: []string{"^(a|b), $"}}}},
+		{`in('a, b'|"it's"|'\'q\'')`, []TagRule{{Rule: "in(a, b|it's|'q')", Name: "in", Params: []string{"a, b", "it's", "'q'"}}}},
+		{`matches(^\(\d+\)$)`, []TagRule{{Rule: `matches(^\(\d+\)$)`, Name: "matches", Params: []string{`^\(\d+\)$`}}}},
+		{`matches(a\,b)`, []TagRule{{Rule: "matches(a,b)", Name: "matches", Params: []string{"a,b"}}}},
+		{"required~Name, please", []TagRule{{Rule: "required", Name: "required", Message: "Name"}, {Rule: "please", Name: "please"}}},
+		{`required~Name\, please`, []TagRule{{Rule: "required", Name: "required", Message: "Name, please"}}},
+		{`required~'Name, please',email~don't (ever)`, []TagRule{
+			{Rule: "required", Name: "required", Message: "Name, please"},
+			{Rule: "email", Name: "email", Message: "don't (ever)"},
+		}},
+		{"email~'%s' is not a valid email", []TagRule{{Rule: "email", Name: "email", Message: "'%s' is not a valid email"}}},
+		{"required~'Name, please", []TagRule{{Rule: "required", Name: "required", Message: "'Name"}, {Rule: "please", Name: "please"}}},
+		{"uuid@create|update~bad id", []TagRule{{Rule: "uuid", Name: "uuid", Message: "bad id", Groups: []string{"create", "update"}}}},
+		{"(length(1|5)|uuid)", []TagRule{{Rule: "(length(1|5)|uuid)"}}},
+	}
+	for _, test := range tests {
+		actual, err := ParseTag(test.tag)
+		if err != nil {
+			t.Errorf("ParseTag(%q): unexpected error %v", test.tag, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("ParseTag(%q): expected %#v, got %#v", test.tag, test.expected, actual)
+		}
+	}
+}
+
+func TestParseTagErrors(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		tag    string
+		offset int
+		rules  int
+	}{
+		{"required,length(1|10", 20, 1},
+		{"required,email)", 14, 1},
+		{"in('a|b)", 3, 0},
+		{"in('a'b)", 6, 0},
+		{"required,~message", 9, 1},
+		{"required,a;b", 9, 1},
+	}
+	for _, test := range tests {
+		rules, err := ParseTag(test.tag)
+		var syntaxErr *TagSyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("ParseTag(%q): expected a TagSyntaxError, got %v", test.tag, err)
+			continue
+		}
+		if syntaxErr.Offset != test.offset || len(rules) != test.rules {
+			t.Errorf("ParseTag(%q): expected offset %d and %d rules, got %d and %d: %v",
+				test.tag, test.offset, test.rules, syntaxErr.Offset, len(rules), err)
+		}
+	}
+}
+
+func TestQuotedParams(t *testing.T) {
+	t.Parallel()
+
+	type choice struct {
+		Value string `valid:"in('a|b'|c)"`
+		Code  string `valid:"matches('^(x|y), z$')"`
+		Size  string `valid:"!in('('|')')"`
+	}
+
+	var tests = []struct {
+		param    choice
+		expected bool
+	}{
+		{choice{"a|b", "x, z", "a"}, true},
+		{choice{"c", "y, z", ""}, true},
+		{choice{"a", "x, z", ""}, false},
+		{choice{"a|b|c", "x, z", ""}, false},
+		{choice{"c", "x", ""}, false},
+		{choice{"c", "x, z", "("}, false},
+	}
+	for _, test := range tests {
+		if actual, err := ValidateStruct(test.param); actual != test.expected {
+			t.Errorf("Expected ValidateStruct(%+v) to be %t, got %t: %v", test.param, test.expected, actual, err)
+		}
+	}
+
+	vd := New()
+	vd.RegisterParamTag("between", regexp.MustCompile(`^between\((\d+)-(\d+)\)$`), func(str string, params ...string) bool {
+		if len(params) != 2 {
+			return false
+		}
+		min, _ := ToInt(params[0])
+		max, _ := ToInt(params[1])
+		return InRange(int64(len(str)), min, max)
+	})
+	type custom struct {
+		Value string `valid:"between(1-5)"`
+	}
+	if ok, err := vd.ValidateStruct(custom{"abc"}); !ok {
+		t.Errorf("Expected the submatches of the regular expression to be handed to the validator, got %v", err)
+	}
+	if ok, _ := vd.ValidateStruct(custom{"abcdef"}); ok {
+		t.Error("Expected between(1-5) to fail for a string of 6 characters")
+	}
+}
+
+func TestLegacyMessagesStartingWithQuote(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Email string `valid:"email~'%s' is not a valid email"`
+		Name  string `valid:"alpha~\"%s\" is not a name,required"`
+	}
+	_, err := ValidateStruct(user{"foo", "x1"})
+	if err == nil || err.Error() != `'foo' is not a valid email;"x1" is not a name` {
+		t.Errorf("Expected the legacy messages to be kept, got %v", err)
+	}
+}
+
+func TestMalformedTags(t *testing.T) {
+	t.Parallel()
+
+	type post struct {
+		Code  string   `valid:"matches(^[a-z]{1,3}$)~Code must have 1\\, 2 or 3 letters"`
+		Title string   `valid:"required,length(1|10"`
+		Tags  []string `valid:"dive,in('a'b)"`
+	}
+	ok, err := ValidateStruct(post{"abcd", "", nil})
+	if ok {
+		t.Fatal("expected malformed tags to fail validation")
+	}
+	errs := flattenErrors(err)
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %v", errs)
+	}
+	if errs[0].Error() != "Code must have 1, 2 or 3 letters" {
+		t.Errorf("expected the custom message to hold a comma, got %q", errs[0])
+	}
+	for _, e := range errs[1:] {
+		var syntaxErr *TagSyntaxError
+		if !errors.As(e.(Error).Err, &syntaxErr) {
+			t.Errorf("expected a TagSyntaxError, got %v", e)
+		}
+	}
+
+	if ok, err := ValidateStruct(post{"abc", "x", nil}); ok || err == nil {
+		t.Errorf("expected a malformed tag to be reported for a valid value")
+	}
+	if err := New().RegisterAlias("broken", "required,length(1"); err == nil {
+		t.Error("expected a malformed alias to be rejected")
+	}
+}
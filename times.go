@@ -0,0 +1,119 @@
+package govalidator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+// timeParam is the parameter of a time tag: an RFC 3339 timestamp for before and after,
+// a duration such as "72h" for within, mindur and maxdur.
+type timeParam struct {
+	t time.Time
+	d time.Duration
+}
+
+// timeTagMap maps the tags applying to time.Time values to their implementation.
+// now is the current time as reported by the clock of the validator.
+var timeTagMap = map[string]func(t, now time.Time, p timeParam) bool{
+	"past":   func(t, now time.Time, p timeParam) bool { return t.Before(now) },
+	"future": func(t, now time.Time, p timeParam) bool { return t.After(now) },
+	"before": func(t, now time.Time, p timeParam) bool { return t.Before(p.t) },
+	"after":  func(t, now time.Time, p timeParam) bool { return t.After(p.t) },
+	"within": func(t, now time.Time, p timeParam) bool {
+		d := t.Sub(now)
+		return d >= -p.d && d <= p.d
+	},
+	"weekday": func(t, now time.Time, p timeParam) bool {
+		return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+	},
+}
+
+// durationTagMap maps the tags applying to time.Duration values to their implementation.
+var durationTagMap = map[string]func(d time.Duration, p timeParam) bool{
+	"mindur": func(d time.Duration, p timeParam) bool { return d >= p.d },
+	"maxdur": func(d time.Duration, p timeParam) bool { return d <= p.d },
+}
+
+// timeTagParams tells which parameter each time tag expects: a "time", a "duration" or none.
+var timeTagParams = map[string]string{
+	"before": "time", "after": "time", "within": "duration", "mindur": "duration", "maxdur": "duration",
+}
+
+var rxTimeTag = regexp.MustCompile(`^(\w+)(?:\((.+)\))?$`)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// matchTimeTag checks whether validator is a time tag like `before(2030-01-01T00:00:00Z)` and parses its parameter.
+func matchTimeTag(validator string) (func(t, now time.Time, p timeParam) bool, func(time.Duration, timeParam) bool, []string, timeParam, bool) {
+	ps := rxTimeTag.FindStringSubmatch(validator)
+	if len(ps) == 0 {
+		return nil, nil, nil, timeParam{}, false
+	}
+	timeFn, isTime := timeTagMap[ps[1]]
+	durationFn, isDuration := durationTagMap[ps[1]]
+	if !isTime && !isDuration {
+		return nil, nil, nil, timeParam{}, false
+	}
+	var p timeParam
+	var err error
+	switch timeTagParams[ps[1]] {
+	case "time":
+		p.t, err = time.Parse(time.RFC3339, ps[2])
+	case "duration":
+		p.d, err = time.ParseDuration(ps[2])
+	default:
+		if ps[2] != "" {
+			return nil, nil, nil, timeParam{}, false
+		}
+		return timeFn, durationFn, nil, p, true
+	}
+	if err != nil {
+		return nil, nil, nil, timeParam{}, false
+	}
+	return timeFn, durationFn, []string{ps[2]}, p, true
+}
+
+// checkTime applies the time rules of f to the time.Time or time.Duration v.
+// Failures are handed to report, validation stops at the first error report returns.
+func (vd *Validate) checkTime(v reflect.Value, f *fieldPlan, applied []bool, report func(error) error) error {
+	for i, r := range f.rules {
+		if r.kind != ruleTime {
+			continue
+		}
+		applied[i] = true
+		var result bool
+		switch {
+		case v.Type() == timeType && r.timeFn != nil:
+			result = r.timeFn(v.Interface().(time.Time), vd.now(), r.timeParam)
+		case v.Type() == durationType && r.durationFn != nil:
+			result = r.durationFn(time.Duration(v.Int()), r.timeParam)
+		default:
+			e := r.newError(f.name, v, fmt.Errorf("Validator %s doesn't support type %s", r.validator, v.Type()), false)
+			e.Code = CodeUnsupportedKind
+			if err := report(e); err != nil {
+				return err
+			}
+			continue
+		}
+		if result == r.negate {
+			if err := report(ruleError(f, r, v, formatTime(v))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// formatTime formats timestamps as RFC 3339 and durations like "1h30m0s" in error messages.
+func formatTime(v reflect.Value) string {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339)
+	}
+	return time.Duration(v.Int()).String()
+}
+
+func isTimeType(t reflect.Type) bool {
+	return t == timeType || t == durationType
+}
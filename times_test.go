@@ -0,0 +1,106 @@
+package govalidator
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeTags(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC) // a Wednesday
+	vd := New(WithClock(func() time.Time { return now }))
+
+	type event struct {
+		Created  time.Time     `valid:"past"`
+		Starts   *time.Time    `valid:"future,within(72h)"`
+		Deadline time.Time     `valid:"before(2030-01-01T00:00:00Z),after(2020-01-01T00:00:00Z)"`
+		Meeting  time.Time     `valid:"weekday"`
+		Holiday  time.Time     `valid:"!weekday"`
+		Timeout  time.Duration `valid:"mindur(1s),maxdur(1h)"`
+	}
+
+	starts := now.Add(24 * time.Hour)
+	valid := event{
+		Created:  now.Add(-time.Hour),
+		Starts:   &starts,
+		Deadline: now,
+		Meeting:  now,
+		Holiday:  now.AddDate(0, 0, 3),
+		Timeout:  30 * time.Second,
+	}
+
+	tooLate := now.Add(96 * time.Hour)
+	earlier := now.Add(-time.Minute)
+	var tests = []struct {
+		name     string
+		modify   func(e *event)
+		expected string
+	}{
+		{"valid", func(e *event) {}, ""},
+		{"created in the future", func(e *event) { e.Created = now.Add(time.Second) }, "Created: 2024-03-06T12:00:01Z does not validate as past"},
+		{"starts in the past", func(e *event) { e.Starts = &earlier }, "Starts: 2024-03-06T11:59:00Z does not validate as future"},
+		{"starts too late", func(e *event) { e.Starts = &tooLate }, "Starts: 2024-03-10T12:00:00Z does not validate as within(72h)"},
+		{"deadline too late", func(e *event) { e.Deadline = time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC) }, "Deadline: 2031-01-01T00:00:00Z does not validate as before(2030-01-01T00:00:00Z)"},
+		{"deadline too early", func(e *event) { e.Deadline = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC) }, "Deadline: 2019-01-01T00:00:00Z does not validate as after(2020-01-01T00:00:00Z)"},
+		{"meeting on sunday", func(e *event) { e.Meeting = now.AddDate(0, 0, 4) }, "Meeting: 2024-03-10T12:00:00Z does not validate as weekday"},
+		{"holiday on monday", func(e *event) { e.Holiday = now.AddDate(0, 0, 5) }, "Holiday: 2024-03-11T12:00:00Z does validate as weekday"},
+		{"timeout too short", func(e *event) { e.Timeout = time.Millisecond }, "Timeout: 1ms does not validate as mindur(1s)"},
+		{"timeout too long", func(e *event) { e.Timeout = 2 * time.Hour }, "Timeout: 2h0m0s does not validate as maxdur(1h)"},
+	}
+	for _, test := range tests {
+		e := valid
+		test.modify(&e)
+		actual, err := vd.ValidateStruct(e)
+		if actual != (test.expected == "") {
+			t.Errorf("%s: expected %t, got %t: %v", test.name, test.expected == "", actual, err)
+			continue
+		}
+		if test.expected != "" && err.Error() != test.expected {
+			t.Errorf("%s: expected error %q, got %q", test.name, test.expected, err.Error())
+		}
+	}
+}
+
+func TestTimeTagsOnWrongType(t *testing.T) {
+	t.Parallel()
+
+	type wrong struct {
+		Name    string        `valid:"past"`
+		Timeout time.Duration `valid:"future"`
+		Created time.Time     `valid:"maxdur(1h)"`
+	}
+
+	var tests = []struct {
+		param    wrong
+		expected string
+	}{
+		{wrong{Name: "x"}, `Name: The following validator is invalid or can't be applied to the field: "past"`},
+		{wrong{Timeout: time.Second}, "Timeout: Validator future doesn't support type time.Duration"},
+		{wrong{Created: time.Now()}, "Created: Validator maxdur(1h) doesn't support type time.Time"},
+	}
+	for _, test := range tests {
+		ok, err := ValidateStruct(test.param)
+		if ok || err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("expected error %q, got %t: %v", test.expected, ok, err)
+		}
+	}
+}
+
+func TestTimeTagsInMap(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	vd := New(WithClock(func() time.Time { return now }))
+	template := map[string]interface{}{"expires": "future", "ttl": "maxdur(1h)"}
+
+	ok, err := vd.ValidateMap(map[string]interface{}{"expires": now.Add(time.Hour), "ttl": time.Minute}, template)
+	if !ok || err != nil {
+		t.Errorf("expected map to be valid, got %v", err)
+	}
+	ok, err = vd.ValidateMap(map[string]interface{}{"expires": now.Add(-time.Hour), "ttl": time.Minute}, template)
+	if ok || err == nil {
+		t.Error("expected an expired timestamp to be invalid")
+	}
+}
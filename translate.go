@@ -0,0 +1,265 @@
+package govalidator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Catalog maps the codes of errors, see Error.Code, to message templates of a locale.
+// Templates may contain the named placeholders of custom error messages: {field}, {path}, {value},
+// {validator}, {len} and {param0}, {param1}, ... for the parameters of the failed rule.
+// The template of the code "*" is used for codes without a template of their own.
+type Catalog map[string]string
+
+// fallbackCode is the catalog key of the template used for codes without a template of their own.
+const fallbackCode = "*"
+
+// defaultLocale is the locale used for locales without a catalog.
+const defaultLocale = "en"
+
+var catalogs = struct {
+	sync.RWMutex
+	locales map[string]Catalog
+}{locales: map[string]Catalog{
+	"en": {
+		fallbackCode:        "{field} is invalid",
+		"required":          "{field} is required",
+		"required_if":       "{field} is required",
+		"required_unless":   "{field} is required",
+		"required_with":     "{field} is required",
+		"required_with_all": "{field} is required",
+		"required_without":  "{field} is required",
+		"excluded_if":       "{field} must be empty",
+		"email":             "{field} must be a valid email address",
+		"url":               "{field} must be a valid URL",
+		"alpha":             "{field} may only contain letters",
+		"alphanum":          "{field} may only contain letters and digits",
+		"numeric":           "{field} may only contain digits",
+		"int":               "{field} must be an integer",
+		"float":             "{field} must be a number",
+		"uuid":              "{field} must be a valid UUID",
+		"in":                "{field} must be one of the allowed values",
+		"not_in":            "{field} must not be {value}",
+		"matches":           "{field} has an invalid format",
+		"length":            "{field} must be between {param0} and {param1} characters long",
+		"stringlength":      "{field} must be between {param0} and {param1} characters long",
+		"runelength":        "{field} must be between {param0} and {param1} characters long",
+		"minstringlength":   "{field} must be at least {param0} characters long",
+		"maxstringlength":   "{field} must be at most {param0} characters long",
+		"range":             "{field} must be between {param0} and {param1}",
+		"min":               "{field} must be at least {param0}",
+		"max":               "{field} must be at most {param0}",
+		"minitems":          "{field} must have at least {param0} items",
+		"maxitems":          "{field} must have at most {param0} items",
+		"nonempty":          "{field} must not be empty",
+		"unique":            "{field} must not contain duplicates",
+		"eqfield":           "{field} must be equal to {param0}",
+		"nefield":           "{field} must not be equal to {param0}",
+		"past":              "{field} must be in the past",
+		"future":            "{field} must be in the future",
+	},
+	"de": {
+		fallbackCode:        "{field} ist ungültig",
+		"required":          "{field} ist erforderlich",
+		"required_if":       "{field} ist erforderlich",
+		"required_unless":   "{field} ist erforderlich",
+		"required_with":     "{field} ist erforderlich",
+		"required_with_all": "{field} ist erforderlich",
+		"required_without":  "{field} ist erforderlich",
+		"excluded_if":       "{field} muss leer sein",
+		"email":             "{field} muss eine gültige E-Mail-Adresse sein",
+		"url":               "{field} muss eine gültige URL sein",
+		"alpha":             "{field} darf nur Buchstaben enthalten",
+		"alphanum":          "{field} darf nur Buchstaben und Ziffern enthalten",
+		"numeric":           "{field} darf nur Ziffern enthalten",
+		"int":               "{field} muss eine ganze Zahl sein",
+		"float":             "{field} muss eine Zahl sein",
+		"uuid":              "{field} muss eine gültige UUID sein",
+		"in":                "{field} muss einer der erlaubten Werte sein",
+		"not_in":            "{field} darf nicht {value} sein",
+		"matches":           "{field} hat ein ungültiges Format",
+		"length":            "{field} muss zwischen {param0} und {param1} Zeichen lang sein",
+		"stringlength":      "{field} muss zwischen {param0} und {param1} Zeichen lang sein",
+		"runelength":        "{field} muss zwischen {param0} und {param1} Zeichen lang sein",
+		"minstringlength":   "{field} muss mindestens {param0} Zeichen lang sein",
+		"maxstringlength":   "{field} darf höchstens {param0} Zeichen lang sein",
+		"range":             "{field} muss zwischen {param0} und {param1} liegen",
+		"min":               "{field} muss mindestens {param0} sein",
+		"max":               "{field} darf höchstens {param0} sein",
+		"minitems":          "{field} muss mindestens {param0} Einträge haben",
+		"maxitems":          "{field} darf höchstens {param0} Einträge haben",
+		"nonempty":          "{field} darf nicht leer sein",
+		"unique":            "{field} darf keine Duplikate enthalten",
+		"eqfield":           "{field} muss gleich {param0} sein",
+		"nefield":           "{field} darf nicht gleich {param0} sein",
+		"past":              "{field} muss in der Vergangenheit liegen",
+		"future":            "{field} muss in der Zukunft liegen",
+	},
+	"fr": {
+		fallbackCode:        "{field} est invalide",
+		"required":          "{field} est obligatoire",
+		"required_if":       "{field} est obligatoire",
+		"required_unless":   "{field} est obligatoire",
+		"required_with":     "{field} est obligatoire",
+		"required_with_all": "{field} est obligatoire",
+		"required_without":  "{field} est obligatoire",
+		"excluded_if":       "{field} doit être vide",
+		"email":             "{field} doit être une adresse e-mail valide",
+		"url":               "{field} doit être une URL valide",
+		"alpha":             "{field} ne peut contenir que des lettres",
+		"alphanum":          "{field} ne peut contenir que des lettres et des chiffres",
+		"numeric":           "{field} ne peut contenir que des chiffres",
+		"int":               "{field} doit être un nombre entier",
+		"float":             "{field} doit être un nombre",
+		"uuid":              "{field} doit être un UUID valide",
+		"in":                "{field} doit être l'une des valeurs autorisées",
+		"not_in":            "{field} ne peut pas être {value}",
+		"matches":           "{field} a un format invalide",
+		"length":            "{field} doit contenir entre {param0} et {param1} caractères",
+		"stringlength":      "{field} doit contenir entre {param0} et {param1} caractères",
+		"runelength":        "{field} doit contenir entre {param0} et {param1} caractères",
+		"minstringlength":   "{field} doit contenir au moins {param0} caractères",
+		"maxstringlength":   "{field} doit contenir au plus {param0} caractères",
+		"range":             "{field} doit être compris entre {param0} et {param1}",
+		"min":               "{field} doit être au moins {param0}",
+		"max":               "{field} doit être au plus {param0}",
+		"minitems":          "{field} doit contenir au moins {param0} éléments",
+		"maxitems":          "{field} doit contenir au plus {param0} éléments",
+		"nonempty":          "{field} ne peut pas être vide",
+		"unique":            "{field} ne peut pas contenir de doublons",
+		"eqfield":           "{field} doit être égal à {param0}",
+		"nefield":           "{field} doit être différent de {param0}",
+		"past":              "{field} doit être dans le passé",
+		"future":            "{field} doit être dans le futur",
+	},
+	"es": {
+		fallbackCode:        "{field} no es válido",
+		"required":          "{field} es obligatorio",
+		"required_if":       "{field} es obligatorio",
+		"required_unless":   "{field} es obligatorio",
+		"required_with":     "{field} es obligatorio",
+		"required_with_all": "{field} es obligatorio",
+		"required_without":  "{field} es obligatorio",
+		"excluded_if":       "{field} debe estar vacío",
+		"email":             "{field} debe ser una dirección de correo válida",
+		"url":               "{field} debe ser una URL válida",
+		"alpha":             "{field} solo puede contener letras",
+		"alphanum":          "{field} solo puede contener letras y dígitos",
+		"numeric":           "{field} solo puede contener dígitos",
+		"int":               "{field} debe ser un número entero",
+		"float":             "{field} debe ser un número",
+		"uuid":              "{field} debe ser un UUID válido",
+		"in":                "{field} debe ser uno de los valores permitidos",
+		"not_in":            "{field} no puede ser {value}",
+		"matches":           "{field} tiene un formato no válido",
+		"length":            "{field} debe tener entre {param0} y {param1} caracteres",
+		"stringlength":      "{field} debe tener entre {param0} y {param1} caracteres",
+		"runelength":        "{field} debe tener entre {param0} y {param1} caracteres",
+		"minstringlength":   "{field} debe tener al menos {param0} caracteres",
+		"maxstringlength":   "{field} debe tener como máximo {param0} caracteres",
+		"range":             "{field} debe estar entre {param0} y {param1}",
+		"min":               "{field} debe ser al menos {param0}",
+		"max":               "{field} debe ser como máximo {param0}",
+		"minitems":          "{field} debe tener al menos {param0} elementos",
+		"maxitems":          "{field} debe tener como máximo {param0} elementos",
+		"nonempty":          "{field} no puede estar vacío",
+		"unique":            "{field} no puede contener duplicados",
+		"eqfield":           "{field} debe ser igual a {param0}",
+		"nefield":           "{field} no puede ser igual a {param0}",
+		"past":              "{field} debe estar en el pasado",
+		"future":            "{field} debe estar en el futuro",
+	},
+}}
+
+// RegisterCatalog adds the templates of catalog to the catalog of locale, e.g. "en" or "pt-BR",
+// replacing templates of the same codes.
+func RegisterCatalog(locale string, catalog Catalog) {
+	locale = normalizeLocale(locale)
+	catalogs.Lock()
+	defer catalogs.Unlock()
+	c, ok := catalogs.locales[locale]
+	if !ok {
+		c = make(Catalog, len(catalog))
+		catalogs.locales[locale] = c
+	}
+	for code, template := range catalog {
+		c[code] = template
+	}
+}
+
+// RegisterCatalogJSON adds the templates of a JSON object mapping codes to templates, e.g. read from a file
+// `{"required": "{field} é obrigatório"}`, to the catalog of locale, see RegisterCatalog.
+func RegisterCatalogJSON(locale string, data []byte) error {
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return fmt.Errorf("invalid catalog for locale %s: %w", locale, err)
+	}
+	RegisterCatalog(locale, catalog)
+	return nil
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// catalogTemplate returns the template of code for locale. A regional locale like "de-AT" falls back to its
+// language for codes it has no template of, and locales without a template of their own, neither for code
+// nor for "*", fall back to English.
+func catalogTemplate(locale, code string) (string, bool) {
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+	locale = normalizeLocale(locale)
+	candidates := []string{locale}
+	if i := strings.IndexByte(locale, '-'); i > 0 {
+		candidates = append(candidates, locale[:i])
+	}
+	for _, key := range []string{code, fallbackCode} {
+		for _, name := range candidates {
+			if t, ok := catalogs.locales[name][key]; ok {
+				return t, true
+			}
+		}
+	}
+	return lookupTemplate(catalogs.locales[defaultLocale], code)
+}
+
+func lookupTemplate(c Catalog, code string) (string, bool) {
+	if t, ok := c[code]; ok {
+		return t, true
+	}
+	t, ok := c[fallbackCode]
+	return t, ok
+}
+
+// Translate returns a copy of err, an Error or Errors as returned by ValidateStruct or ValidateMap,
+// whose messages are rendered from the catalog of locale. Custom error messages given in tags are kept,
+// as are errors without a code or without a template in the catalog, errors reporting mistakes in
+// tags rather than invalid values and the errors returned by Validate methods. Translated errors print their message only, like errors with a custom
+// message, but keep CustomErrorMessageExists false, so that they can be translated again.
+func Translate(err error, locale string) error {
+	switch e := err.(type) {
+	case Error:
+		switch {
+		case e.CustomErrorMessageExists || e.Code == "":
+			return e
+		case e.Code == CodeInvalidTag || e.Code == CodeInvalidValidator || e.Code == CodeUnsupportedKind ||
+			e.Code == CodeSanitize || e.Code == CodeValidate:
+			return e
+		}
+		t, ok := catalogTemplate(locale, e.Code)
+		if !ok {
+			return e
+		}
+		e.Err = &customMessage{template: t, text: e.expandPlaceholders(t)}
+		return e
+	case Errors:
+		translated := make(Errors, len(e))
+		for i, err := range e {
+			translated[i] = Translate(err, locale)
+		}
+		return translated
+	}
+	return err
+}
@@ -0,0 +1,124 @@
+package govalidator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Zip string `valid:"numeric"`
+	}
+	type user struct {
+		Name    string   `valid:"required"`
+		Nick    string   `valid:"stringlength(3|10)"`
+		Role    string   `valid:"!in(root|admin)"`
+		Email   string   `valid:"email~Email is broken"`
+		Tags    []string `valid:"minitems(2)"`
+		Address address
+		Token   string `valid:"nosuchvalidator"`
+	}
+	_, err := ValidateStruct(user{Nick: "ab", Role: "root", Email: "x", Tags: []string{"a"}, Address: address{"x"}, Token: "t"})
+
+	var tests = []struct {
+		locale   string
+		expected map[string]string
+	}{
+		{"en", map[string]string{
+			"Name":  "Name is required",
+			"Nick":  "Nick must be between 3 and 10 characters long",
+			"Role":  "Role must not be root",
+			"Email": "Email is broken",
+			"Tags":  "Tags must have at least 2 items",
+			"Zip":   "Zip may only contain digits",
+			"Token": `Token: The following validator is invalid or can't be applied to the field: "nosuchvalidator"`,
+		}},
+		{"de_AT", map[string]string{
+			"Name":  "Name ist erforderlich",
+			"Nick":  "Nick muss zwischen 3 und 10 Zeichen lang sein",
+			"Role":  "Role darf nicht root sein",
+			"Email": "Email is broken",
+			"Tags":  "Tags muss mindestens 2 Einträge haben",
+			"Zip":   "Zip darf nur Ziffern enthalten",
+		}},
+		{"xx", map[string]string{"Name": "Name is required"}},
+	}
+	for _, test := range tests {
+		translated := Translate(err, test.locale)
+		messages := map[string]string{}
+		for _, e := range flattenErrors(translated) {
+			messages[e.(Error).Name] = e.Error()
+		}
+		for name, expected := range test.expected {
+			if messages[name] != expected {
+				t.Errorf("%s: expected %s to be %q, got %q", test.locale, name, expected, messages[name])
+			}
+		}
+	}
+	if flattenErrors(err)[0].Error() != "Name: non zero value required" {
+		t.Errorf("expected Translate to leave err alone, got %v", err)
+	}
+}
+
+func TestRegisterCatalogJSON(t *testing.T) {
+	t.Parallel()
+
+	if err := RegisterCatalogJSON("pt-BR", []byte(`{"required": "{field} é obrigatório", "*": "{field} é inválido"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterCatalogJSON("pt-BR", []byte(`{"required": 1}`)); err == nil {
+		t.Error("expected an error for a malformed catalog")
+	}
+	_, err := ValidateMap(map[string]interface{}{"age": "x"}, map[string]interface{}{"name": "required", "age": "int"})
+	messages := map[string]string{}
+	for _, e := range flattenErrors(Translate(err, "pt-BR")) {
+		messages[e.(Error).Name] = e.Error()
+	}
+	if messages["name"] != "name é obrigatório" || messages["age"] != "age é inválido" {
+		t.Errorf("expected messages of the registered catalog, got %v", messages)
+	}
+}
+
+func TestTranslateRegionalCatalogFallsBackPerCode(t *testing.T) {
+	t.Parallel()
+
+	RegisterCatalog("de-CH", Catalog{"required": "{field} muss angegeben werden"})
+	type user struct {
+		Name  string `valid:"required"`
+		Email string `valid:"email"`
+	}
+	_, err := ValidateStruct(user{Email: "x"})
+	messages := map[string]string{}
+	for _, e := range flattenErrors(Translate(err, "de-CH")) {
+		if e.(Error).CustomErrorMessageExists {
+			t.Errorf("expected %s to report no custom message", e.(Error).Name)
+		}
+		messages[e.(Error).Name] = e.Error()
+	}
+	if messages["Name"] != "Name muss angegeben werden" {
+		t.Errorf("expected the message of the regional catalog, got %q", messages["Name"])
+	}
+	if messages["Email"] != "Email muss eine gültige E-Mail-Adresse sein" {
+		t.Errorf("expected the message of the language catalog, got %q", messages["Email"])
+	}
+	if retranslated := flattenErrors(Translate(Translate(err, "de-CH"), "en")); retranslated[0].Error() != "Name is required" {
+		t.Errorf("expected translated errors to be translated again, got %q", retranslated[0].Error())
+	}
+}
+
+func TestTranslateKeepsErrorsOfValidateMethods(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	vd := New()
+	_, err := vd.ValidateStruct(testDateRange{now, now})
+	if translated := Translate(err, "de").Error(); translated != "end must be after start" {
+		t.Errorf("expected the error of the root Validate method to be kept, got %q", translated)
+	}
+	_, err = vd.ValidateStruct(testBooking{Price: 101, Period: testDateRange{now, now.Add(time.Hour)}})
+	if translated := Translate(err, "de").Error(); translated != "Price: must be a multiple of 5 cents" {
+		t.Errorf("expected the error of the field Validate method to be kept, got %q", translated)
+	}
+}
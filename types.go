@@ -1,9 +1,9 @@
 package govalidator
 
 import (
+	"context"
 	"reflect"
 	"regexp"
-	"sort"
 	"sync"
 )
 
@@ -14,33 +14,17 @@ type Validator[T ~string] func(str T) bool
 // The second parameter should be the context (in the case of validating a struct: the whole object being validated).
 type CustomTypeValidator[T any] func(i T, o T) bool
 
+// CustomTypeValidatorCtx is a CustomTypeValidator which also receives the context passed to ValidateStructCtx
+// or ValidateMapCtx (context.Background() for ValidateStruct and ValidateMap), e.g. to bound lookups by the
+// deadline of a request or to read request-scoped values.
+type CustomTypeValidatorCtx[T any] func(ctx context.Context, i T, o T) bool
+
 // ParamValidator is a wrapper for validator functions that accept additional parameters.
 type ParamValidator[T ~string] func(str T, params ...string) bool
 
 // InterfaceParamValidator is a wrapper for functions that accept variants parameters for an interface value
 type InterfaceParamValidator[T any] func(in T, params ...string) bool
 
-type tagOptionsMap map[string]tagOption
-
-func (t tagOptionsMap) orderedKeys() []string {
-	var keys []string
-	for k := range t {
-		keys = append(keys, k)
-	}
-
-	sort.Slice(keys, func(a, b int) bool {
-		return t[keys[a]].order < t[keys[b]].order
-	})
-
-	return keys
-}
-
-type tagOption struct {
-	name               string
-	customErrorMessage string
-	order              int
-}
-
 // UnsupportedTypeError is a wrapper for reflect.Type
 type UnsupportedTypeError struct {
 	Type reflect.Type
@@ -66,8 +50,8 @@ var ParamTagMap = map[string]ParamValidator[string]{
 	"range":           Range[string],
 	"runelength":      RuneLength[string],
 	"stringlength":    StringLength[string],
-	"matches":         StringMatches[string],
-	"in":              IsInRaw[string],
+	"matches":         matchesParams,
+	"in":              isInParams,
 	"rsapub":          IsRsaPub[string],
 	"minstringlength": MinStringLength[string],
 	"maxstringlength": MaxStringLength[string],
@@ -75,8 +59,8 @@ var ParamTagMap = map[string]ParamValidator[string]{
 
 // ParamTagRegexMap maps param tags to their respective regexes.
 var ParamTagRegexMap = map[string]*regexp.Regexp{
-	"range":           regexp.MustCompile(`^range\\((\\d+(?:.\\d+)?)\\|(\\d+(?:.\\d+)?)\\)$`),
-	"length":          regexp.MustCompile(`^length\\((\\d+)\\|(\\d+)\\)$`),
+	"range":           regexp.MustCompile(`^range\(([-+]?\d+(?:\.\d+)?)\|([-+]?\d+(?:\.\d+)?)\)$`),
+	"length":          regexp.MustCompile(`^length\((\d+)\|(\d+)\)$`),
 	"runelength":      regexp.MustCompile("^runelength\\((\\d+)\\|(\\d+)\\)$"),
 	"stringlength":    regexp.MustCompile("^stringlength\\((\\d+)\\|(\\d+)\\)$"),
 	"in":              regexp.MustCompile(`^in\((.*)\)`),
@@ -87,7 +71,10 @@ var ParamTagRegexMap = map[string]*regexp.Regexp{
 }
 
 type customTypeTagMap[T any] struct {
-	validators map[string]CustomTypeValidator[T]
+	validators    map[string]CustomTypeValidator[T]
+	ctxValidators map[string]CustomTypeValidatorCtx[T]
+	// gen is incremented on every Set, so that cached validation plans can be invalidated
+	gen uint64
 
 	sync.RWMutex
 }
@@ -103,6 +90,40 @@ func (tm *customTypeTagMap[T]) Set(name string, ctv CustomTypeValidator[T]) {
 	tm.Lock()
 	defer tm.Unlock()
 	tm.validators[name] = ctv
+	delete(tm.ctxValidators, name)
+	tm.gen++
+}
+
+// GetCtx returns the validator registered under name, adapting validators registered with Set.
+func (tm *customTypeTagMap[T]) GetCtx(name string) (CustomTypeValidatorCtx[T], bool) {
+	tm.RLock()
+	defer tm.RUnlock()
+	if v, ok := tm.ctxValidators[name]; ok {
+		return v, true
+	}
+	v, ok := tm.validators[name]
+	if !ok {
+		return nil, false
+	}
+	return func(_ context.Context, i T, o T) bool { return v(i, o) }, true
+}
+
+// SetCtx registers a context-aware validator, replacing any validator registered under the same name.
+func (tm *customTypeTagMap[T]) SetCtx(name string, ctv CustomTypeValidatorCtx[T]) {
+	tm.Lock()
+	defer tm.Unlock()
+	if tm.ctxValidators == nil {
+		tm.ctxValidators = make(map[string]CustomTypeValidatorCtx[T])
+	}
+	tm.ctxValidators[name] = ctv
+	delete(tm.validators, name)
+	tm.gen++
+}
+
+func (tm *customTypeTagMap[T]) generation() uint64 {
+	tm.RLock()
+	defer tm.RUnlock()
+	return tm.gen
 }
 
 // CustomTypeTagMap is a map of functions that can be used as tags for ValidateStruct function.
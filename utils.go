@@ -266,5 +266,8 @@ func buildPadStr[T ~string](str T, padStr T, padLen int, padLeft bool, padRight
 // TruncatingErrorf removes extra args from fmt.Errorf if not formatted in the str object
 func TruncatingErrorf[T ~string](str T, args ...interface{}) error {
 	n := strings.Count(string(str), "%s")
+	if n > len(args) {
+		n = len(args)
+	}
 	return fmt.Errorf(string(str), args[:n]...)
 }
@@ -0,0 +1,84 @@
+package govalidator
+
+import (
+	"context"
+	"reflect"
+)
+
+// Validatable is implemented by types checking invariants which cannot be expressed in tags.
+// ValidateStruct calls Validate after the tag rules of a struct (or of a field of such a type) were applied,
+// whether they passed or not, and merges the returned Error or Errors into its result, prefixed with the
+// path of the value. Other errors are reported as an Error of the value. SetValidateMethods(false) disables it.
+//
+// A Validate method may validate its own value again with the tag rules, but only by passing the context
+// it is given to ValidateStructCtx, see ValidatableWithContext: the method is not called again then.
+// Calling ValidateStruct on its own value from Validate recurses endlessly.
+type Validatable interface {
+	Validate() error
+}
+
+// ValidatableWithContext is a Validatable receiving the context passed to ValidateStructCtx.
+// It takes precedence over Validatable.
+type ValidatableWithContext interface {
+	Validate(ctx context.Context) error
+}
+
+// validatableTag is reported in Error.Validator for errors returned by Validatable types.
+const validatableTag = "validate"
+
+// validatingKey marks the context handed to a Validate method with the type of its value, so that
+// a Validate method validating its own value again with ValidateStructCtx does not recurse.
+type validatingKey struct{}
+
+// revalidating tells whether the struct v is validated again by its own Validate method, see validatingKey.
+// It returns the context to validate v with, without the mark, so that nested values of the same type
+// have their Validate method called.
+func revalidating(ctx context.Context, v reflect.Value) (context.Context, bool) {
+	t, _ := ctx.Value(validatingKey{}).(reflect.Type)
+	if t == nil {
+		return ctx, false
+	}
+	return context.WithValue(ctx, validatingKey{}, nil), t == v.Type()
+}
+
+// callValidatable calls the Validate method of v, or of a pointer to v, if it has one and Validate
+// methods are not disabled. name is the name of the field holding v, empty for the struct being validated.
+func (vd *Validate) callValidatable(ctx context.Context, v reflect.Value, name string) error {
+	if vd.skipValidateMethods || !v.IsValid() || ((v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil()) {
+		return nil
+	}
+	ctx = context.WithValue(ctx, validatingKey{}, v.Type())
+	candidates := []reflect.Value{v}
+	if v.CanAddr() {
+		candidates = append(candidates, v.Addr())
+	}
+	for _, c := range candidates {
+		if !c.CanInterface() {
+			continue
+		}
+		var err error
+		switch hook := c.Interface().(type) {
+		case ValidatableWithContext:
+			err = hook.Validate(ctx)
+		case Validatable:
+			err = hook.Validate()
+		default:
+			continue
+		}
+		switch err.(type) {
+		case nil:
+			return nil
+		case Error, Errors:
+			if name == "" {
+				return err
+			}
+			return prependPathToErrors(err, name)
+		}
+		e := Error{Name: name, Err: err, Validator: validatableTag, Path: []string{}, Code: CodeValidate}
+		if v.CanInterface() {
+			e.Value, e.Kind = v.Interface(), v.Kind()
+		}
+		return e
+	}
+	return nil
+}
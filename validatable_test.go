@@ -0,0 +1,156 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testMoney int64
+
+func (m testMoney) Validate() error {
+	if m%5 != 0 {
+		return errors.New("must be a multiple of 5 cents")
+	}
+	return nil
+}
+
+type testDateRange struct {
+	Start time.Time `valid:"required"`
+	End   time.Time `valid:"required"`
+}
+
+func (r testDateRange) Validate() error {
+	if !r.End.After(r.Start) {
+		return errors.New("end must be after start")
+	}
+	return nil
+}
+
+type testAddress struct {
+	City string `valid:"alpha"`
+	Zip  string
+}
+
+func (a *testAddress) Validate(ctx context.Context) error {
+	country, _ := ctx.Value(tenantKey{}).(string)
+	if country == "US" && len(a.Zip) != 5 {
+		return Error{Name: "Zip", Err: fmt.Errorf("%s is not a US zip code", a.Zip), Validator: "zip"}
+	}
+	return nil
+}
+
+type testBooking struct {
+	Price    testMoney     `valid:"required"`
+	Period   testDateRange `json:"period"`
+	Address  *testAddress
+	Optional testMoney `valid:"-"`
+}
+
+func TestValidatable(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := testBooking{
+		Price:    100,
+		Period:   testDateRange{now, now.Add(time.Hour)},
+		Address:  &testAddress{City: "Paris", Zip: "75001"},
+		Optional: 1,
+	}
+	ctx := context.WithValue(context.Background(), tenantKey{}, "US")
+	vd := New()
+
+	var tests = []struct {
+		name     string
+		modify   func(b *testBooking)
+		expected []string
+	}{
+		{"valid", func(b *testBooking) {}, nil},
+		{"field type", func(b *testBooking) { b.Price = 101 }, []string{"Price: must be a multiple of 5 cents"}},
+		{"nested struct", func(b *testBooking) { b.Period.End = now }, []string{"period: end must be after start"}},
+		{"tag rules and hook", func(b *testBooking) { b.Period = testDateRange{Start: now} }, []string{
+			"period.End: non zero value required",
+			"period: end must be after start",
+		}},
+		{"pointer receiver with context", func(b *testBooking) { b.Address = &testAddress{City: "Paris", Zip: "1"} }, []string{
+			"Address.Zip: 1 is not a US zip code",
+		}},
+		{"nil pointer", func(b *testBooking) { b.Address = nil }, nil},
+	}
+	for _, test := range tests {
+		b := valid
+		test.modify(&b)
+		ok, err := vd.ValidateStructCtx(ctx, b)
+		if ok != (test.expected == nil) {
+			t.Errorf("%s: expected %t, got %t: %v", test.name, test.expected == nil, ok, err)
+			continue
+		}
+		if test.expected == nil {
+			continue
+		}
+		var messages []string
+		for _, e := range flattenErrors(err) {
+			messages = append(messages, e.Error())
+		}
+		if strings.Join(messages, "\n") != strings.Join(test.expected, "\n") {
+			t.Errorf("%s: expected errors\n%s\ngot\n%s", test.name, strings.Join(test.expected, "\n"), strings.Join(messages, "\n"))
+		}
+	}
+
+	ok, err := vd.ValidateStruct(testDateRange{now, now})
+	if ok || err == nil || err.Error() != "end must be after start" {
+		t.Errorf("expected the error of the root struct without a name, got %v", err)
+	}
+	if ok, _ := vd.ValidateStructPartial(testDateRange{now, now}, "Start"); !ok {
+		t.Error("expected Validate not to be called on a partially validated struct")
+	}
+	if ok, _ := New(WithValidateMethods(false)).ValidateStruct(testDateRange{now, now}); !ok {
+		t.Error("expected Validate methods not to be called once disabled")
+	}
+}
+
+type testAccount struct {
+	Name string `valid:"required"`
+	vd   *Validate
+}
+
+// Validate validates the account again without passing on a context, like types written before
+// Validate methods were called by ValidateStruct, which requires Validate methods to be disabled.
+func (a testAccount) Validate() error {
+	_, err := a.vd.ValidateStruct(a)
+	return err
+}
+
+type testProfile struct {
+	Nick  string `valid:"required"`
+	vd    *Validate
+	Child *testProfile
+}
+
+func (p testProfile) Validate(ctx context.Context) error {
+	if _, err := p.vd.ValidateStructCtx(ctx, p); err != nil {
+		return err
+	}
+	if p.Nick == "root" {
+		return errors.New("root is reserved")
+	}
+	return nil
+}
+
+func TestValidatableRevalidatingItself(t *testing.T) {
+	t.Parallel()
+
+	if ok, err := New().ValidateStruct(testAccount{vd: New(WithValidateMethods(false))}); ok || err == nil {
+		t.Errorf("expected the tag rules to fail, got %v", err)
+	}
+	vd := New()
+	p := testProfile{Nick: "ok", vd: vd, Child: &testProfile{Nick: "root", vd: vd}}
+	_, err := vd.ValidateStructCtx(context.Background(), p)
+	// the nested profile is validated by the traversal and again by the Validate method of its parent
+	if err == nil || err.Error() != "Child: root is reserved;Child: root is reserved" {
+		t.Errorf("expected the Validate method of the nested profile to be called, got %v", err)
+	}
+}
@@ -0,0 +1,340 @@
+package govalidator
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Validate holds the configuration used to validate structs and maps: the tag name,
+// the required-by-default and nil pointer policies and the registries of validators.
+// Each Validate owns its own registries, so several instances with different policies
+// can be used side by side in one process.
+// The package level functions (ValidateStruct, ValidateMap, ...) use a default instance
+// which shares the package level TagMap, ParamTagMap and CustomTypeTagMap registries.
+type Validate struct {
+	tagName                 string
+	fieldsRequiredByDefault bool
+	nilPtrAllowedByRequired bool
+	// now is the clock the past, future and within tags compare with
+	now       func() time.Time
+	errorMode ErrorMode
+	// errorOrder tells in which order errors are reported, see ErrorOrder
+	errorOrder ErrorOrder
+	// fieldNameFunc names struct fields in errors, see WithFieldNameFunc
+	fieldNameFunc FieldNameFunc
+	// skipValidateMethods disables calling the Validate methods of Validatable types
+	skipValidateMethods bool
+
+	tagMap                    map[string]Validator[string]
+	paramTagMap               map[string]ParamValidator[string]
+	paramTagRegexMap          map[string]*regexp.Regexp
+	interfaceParamTagMap      map[string]InterfaceParamValidator[any]
+	interfaceParamTagRegexMap map[string]*regexp.Regexp
+	customTypeTagMap          *customTypeTagMap[any]
+	sanitizerMap              map[string]Sanitizer
+	paramSanitizerMap         map[string]ParamSanitizer
+	aliasMap                  map[string]string
+
+	mu    sync.RWMutex
+	plans atomic.Pointer[planCache]
+}
+
+// Option configures a Validate created by New.
+type Option func(*Validate)
+
+// WithTagName sets the struct tag the validator reads its rules from, "valid" by default.
+func WithTagName(name string) Option {
+	return func(vd *Validate) {
+		vd.tagName = name
+	}
+}
+
+// WithFieldsRequiredByDefault has the same effect as SetFieldsRequiredByDefault, but for the new instance only.
+func WithFieldsRequiredByDefault(value bool) Option {
+	return func(vd *Validate) {
+		vd.fieldsRequiredByDefault = value
+	}
+}
+
+// WithNilPtrAllowedByRequired has the same effect as SetNilPtrAllowedByRequired, but for the new instance only.
+func WithNilPtrAllowedByRequired(value bool) Option {
+	return func(vd *Validate) {
+		vd.nilPtrAllowedByRequired = value
+	}
+}
+
+// WithValidateMethods has the same effect as SetValidateMethods, but for the new instance only.
+func WithValidateMethods(value bool) Option {
+	return func(vd *Validate) {
+		vd.skipValidateMethods = !value
+	}
+}
+
+// WithClock sets the clock the past, future and within tags compare with, time.Now by default.
+// It makes validation of timestamps deterministic in tests.
+func WithClock(now func() time.Time) Option {
+	return func(vd *Validate) {
+		vd.now = now
+	}
+}
+
+// FieldNameFunc returns the name of a struct field reported in errors, an empty name stands for the Go name.
+type FieldNameFunc func(field reflect.StructField) string
+
+// FieldNameFromTag returns a FieldNameFunc reading the name of a field from the struct tag key,
+// e.g. "json", "yaml", "form", "xml" or a "label" tag. Options following a comma are ignored,
+// and fields named "-" are reported under their Go name.
+func FieldNameFromTag(key string) FieldNameFunc {
+	return func(field reflect.StructField) string {
+		return toJSONName(field.Tag.Get(key))
+	}
+}
+
+// WithFieldNameFunc sets how struct fields are named in Error.Name and in every segment of Error.Path,
+// e.g. WithFieldNameFunc(FieldNameFromTag("yaml")) reports the names of a YAML document at every depth.
+// By default fields are named by their json tag, and by their Go name if they have none.
+func WithFieldNameFunc(fn FieldNameFunc) Option {
+	return func(vd *Validate) {
+		vd.fieldNameFunc = fn
+	}
+}
+
+// ErrorMode tells how many errors are reported when validation fails.
+type ErrorMode int
+
+const (
+	// FirstErrorPerField reports the first failing rule of every field. It is the default.
+	FirstErrorPerField ErrorMode = iota
+	// AllErrorsPerField reports every failing rule of every field, e.g. both a too short
+	// password and one without digits.
+	AllErrorsPerField
+	// FailFast stops validating a struct or map at its first error, for cheap rejection of bad input.
+	FailFast
+)
+
+// WithErrorMode sets how many errors the instance reports, see ErrorMode.
+func WithErrorMode(mode ErrorMode) Option {
+	return func(vd *Validate) {
+		vd.errorMode = mode
+	}
+}
+
+// ErrorOrder tells in which order errors are reported when validation fails.
+type ErrorOrder int
+
+const (
+	// DeclarationOrder reports the errors of struct fields in the order the fields are declared, and the errors
+	// of maps validated by ValidateMap in the sorted order of their keys. Errors of nested structs and maps
+	// are reported in place of their parent field. It is the default.
+	DeclarationOrder ErrorOrder = iota
+	// PathOrder reports a flat list of errors sorted by the dotted path of their field, e.g. "Address.City"
+	// before "Name". Numeric segments like slice indexes compare as numbers, and errors of the same field
+	// keep the order of their rules.
+	PathOrder
+)
+
+// WithErrorOrder sets in which order the instance reports errors, see ErrorOrder.
+func WithErrorOrder(order ErrorOrder) Option {
+	return func(vd *Validate) {
+		vd.errorOrder = order
+	}
+}
+
+type errorModeKey struct{}
+
+// ContextWithErrorMode returns a copy of ctx which makes ValidateStructCtx and ValidateMapCtx report
+// errors according to mode, instead of the error mode of the validator.
+func ContextWithErrorMode(ctx context.Context, mode ErrorMode) context.Context {
+	return context.WithValue(ctx, errorModeKey{}, mode)
+}
+
+func (vd *Validate) errorModeFor(ctx context.Context) ErrorMode {
+	if mode, ok := ctx.Value(errorModeKey{}).(ErrorMode); ok {
+		return mode
+	}
+	return vd.errorMode
+}
+
+var defaultValidate = &Validate{
+	tagName:                   tagName,
+	now:                       time.Now,
+	tagMap:                    TagMap,
+	paramTagMap:               ParamTagMap,
+	paramTagRegexMap:          ParamTagRegexMap,
+	interfaceParamTagMap:      InterfaceParamTagMap,
+	interfaceParamTagRegexMap: InterfaceParamTagRegexMap,
+	customTypeTagMap:          CustomTypeTagMap,
+	sanitizerMap:              SanitizerMap,
+	paramSanitizerMap:         ParamSanitizerMap,
+	aliasMap:                  AliasMap,
+}
+
+// New creates a validator with its own copy of the currently registered validators.
+// Validators registered on the instance afterwards are not visible to the package level
+// functions and vice versa.
+func New(opts ...Option) *Validate {
+	vd := &Validate{
+		tagName:                   tagName,
+		now:                       time.Now,
+		tagMap:                    make(map[string]Validator[string], len(TagMap)),
+		paramTagMap:               make(map[string]ParamValidator[string], len(ParamTagMap)),
+		paramTagRegexMap:          make(map[string]*regexp.Regexp, len(ParamTagRegexMap)),
+		interfaceParamTagMap:      make(map[string]InterfaceParamValidator[any], len(InterfaceParamTagMap)),
+		interfaceParamTagRegexMap: make(map[string]*regexp.Regexp, len(InterfaceParamTagRegexMap)),
+		customTypeTagMap: &customTypeTagMap[any]{
+			validators:    make(map[string]CustomTypeValidator[any]),
+			ctxValidators: make(map[string]CustomTypeValidatorCtx[any]),
+		},
+		sanitizerMap:      make(map[string]Sanitizer, len(SanitizerMap)),
+		paramSanitizerMap: make(map[string]ParamSanitizer, len(ParamSanitizerMap)),
+		aliasMap:          make(map[string]string, len(AliasMap)),
+	}
+	for k, f := range TagMap {
+		vd.tagMap[k] = f
+	}
+	for k, f := range ParamTagMap {
+		vd.paramTagMap[k] = f
+	}
+	for k, rx := range ParamTagRegexMap {
+		vd.paramTagRegexMap[k] = rx
+	}
+	for k, f := range InterfaceParamTagMap {
+		vd.interfaceParamTagMap[k] = f
+	}
+	for k, rx := range InterfaceParamTagRegexMap {
+		vd.interfaceParamTagRegexMap[k] = rx
+	}
+	for k, f := range SanitizerMap {
+		vd.sanitizerMap[k] = f
+	}
+	for k, f := range ParamSanitizerMap {
+		vd.paramSanitizerMap[k] = f
+	}
+	for k, tag := range AliasMap {
+		vd.aliasMap[k] = tag
+	}
+	CustomTypeTagMap.RLock()
+	for k, f := range CustomTypeTagMap.validators {
+		vd.customTypeTagMap.validators[k] = f
+	}
+	for k, f := range CustomTypeTagMap.ctxValidators {
+		vd.customTypeTagMap.ctxValidators[k] = f
+	}
+	CustomTypeTagMap.RUnlock()
+
+	for _, opt := range opts {
+		opt(vd)
+	}
+	return vd
+}
+
+// SetFieldsRequiredByDefault is the instance counterpart of the package level SetFieldsRequiredByDefault.
+func (vd *Validate) SetFieldsRequiredByDefault(value bool) {
+	vd.fieldsRequiredByDefault = value
+}
+
+// SetValidateMethods is the instance counterpart of the package level SetValidateMethods.
+func (vd *Validate) SetValidateMethods(value bool) {
+	vd.skipValidateMethods = !value
+}
+
+// SetErrorMode sets how many errors the instance reports, see ErrorMode.
+func (vd *Validate) SetErrorMode(mode ErrorMode) {
+	vd.errorMode = mode
+}
+
+// SetErrorOrder sets in which order the instance reports errors, see ErrorOrder.
+func (vd *Validate) SetErrorOrder(order ErrorOrder) {
+	vd.errorOrder = order
+}
+
+// SetFieldNameFunc sets how struct fields are named in errors, see WithFieldNameFunc.
+func (vd *Validate) SetFieldNameFunc(fn FieldNameFunc) {
+	vd.fieldNameFunc = fn
+	vd.resetPlans()
+}
+
+// SetNilPtrAllowedByRequired is the instance counterpart of the package level SetNilPtrAllowedByRequired.
+func (vd *Validate) SetNilPtrAllowedByRequired(value bool) {
+	vd.nilPtrAllowedByRequired = value
+}
+
+// RegisterTag adds a string validator usable as `valid:"name"`.
+// Registering a validator drops the cached validation plans of the instance.
+func (vd *Validate) RegisterTag(name string, fn Validator[string]) {
+	vd.mu.Lock()
+	defer vd.mu.Unlock()
+	vd.tagMap[name] = fn
+	vd.resetPlans()
+}
+
+// RegisterParamTag adds a string validator with parameters, e.g. `valid:"name(a|b)"`.
+// The parameters are the submatches of rx.
+func (vd *Validate) RegisterParamTag(name string, rx *regexp.Regexp, fn ParamValidator[string]) {
+	vd.mu.Lock()
+	defer vd.mu.Unlock()
+	vd.paramTagMap[name] = fn
+	vd.paramTagRegexMap[name] = rx
+	vd.resetPlans()
+}
+
+// RegisterInterfaceParamTag adds a validator with parameters which is applied to a value of any type.
+// The parameters are the submatches of rx.
+func (vd *Validate) RegisterInterfaceParamTag(name string, rx *regexp.Regexp, fn InterfaceParamValidator[any]) {
+	vd.mu.Lock()
+	defer vd.mu.Unlock()
+	vd.interfaceParamTagMap[name] = fn
+	vd.interfaceParamTagRegexMap[name] = rx
+	vd.resetPlans()
+}
+
+// RegisterCustomTypeTag adds a validator that is handed the whole field value and the struct it belongs to.
+func (vd *Validate) RegisterCustomTypeTag(name string, fn CustomTypeValidator[any]) {
+	vd.customTypeTagMap.Set(name, fn)
+}
+
+// RegisterCustomTypeTagCtx adds a custom type validator which also receives the context of the validation.
+func (vd *Validate) RegisterCustomTypeTagCtx(name string, fn CustomTypeValidatorCtx[any]) {
+	vd.customTypeTagMap.SetCtx(name, fn)
+}
+
+func (vd *Validate) lookupTag(name string) (Validator[string], bool) {
+	vd.mu.RLock()
+	defer vd.mu.RUnlock()
+	fn, ok := vd.tagMap[name]
+	return fn, ok
+}
+
+func (vd *Validate) matchParamTag(validator string) (string, *regexp.Regexp, []string, bool) {
+	vd.mu.RLock()
+	defer vd.mu.RUnlock()
+	for key, value := range vd.paramTagRegexMap {
+		ps := value.FindStringSubmatch(validator)
+		if len(ps) == 0 {
+			continue
+		}
+		if _, ok := vd.paramTagMap[key]; ok {
+			return key, value, ps[1:], true
+		}
+	}
+	return "", nil, nil, false
+}
+
+func (vd *Validate) matchInterfaceParamTag(validator string) (string, *regexp.Regexp, []string, bool) {
+	vd.mu.RLock()
+	defer vd.mu.RUnlock()
+	for key, value := range vd.interfaceParamTagRegexMap {
+		ps := value.FindStringSubmatch(validator)
+		if len(ps) == 0 {
+			continue
+		}
+		if _, ok := vd.interfaceParamTagMap[key]; ok {
+			return key, value, ps[1:], true
+		}
+	}
+	return "", nil, nil, false
+}
@@ -0,0 +1,235 @@
+package govalidator
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestNewFieldsRequiredByDefault(t *testing.T) {
+	t.Parallel()
+
+	type exampleStruct struct {
+		Name  string
+		Email string `valid:"email"`
+	}
+
+	strict := New(WithFieldsRequiredByDefault(true))
+	lax := New()
+
+	if ok, err := strict.ValidateStruct(exampleStruct{Email: "foo@bar.com"}); ok || err == nil {
+		t.Errorf("Expected strict validator to fail, got %v %v", ok, err)
+	}
+	if ok, err := lax.ValidateStruct(exampleStruct{Email: "foo@bar.com"}); !ok || err != nil {
+		t.Errorf("Expected lax validator to pass, got %v %v", ok, err)
+	}
+	if ok, err := ValidateStruct(exampleStruct{Email: "foo@bar.com"}); !ok || err != nil {
+		t.Errorf("Expected package level validation to be unaffected by instances, got %v %v", ok, err)
+	}
+}
+
+func TestNewNilPtrAllowedByRequired(t *testing.T) {
+	t.Parallel()
+
+	type exampleStruct struct {
+		Name *string `valid:"required"`
+	}
+
+	if ok, _ := New().ValidateStruct(exampleStruct{}); ok {
+		t.Error("Expected nil pointer to fail required by default")
+	}
+	if ok, err := New(WithNilPtrAllowedByRequired(true)).ValidateStruct(exampleStruct{}); !ok || err != nil {
+		t.Errorf("Expected nil pointer to be allowed, got %v %v", ok, err)
+	}
+}
+
+func TestNewTagName(t *testing.T) {
+	t.Parallel()
+
+	type exampleStruct struct {
+		Email string `check:"email" valid:"-"`
+	}
+
+	vd := New(WithTagName("check"))
+	if ok, err := vd.ValidateStruct(exampleStruct{Email: "foo"}); ok || err == nil {
+		t.Errorf("Expected validation with custom tag name to fail, got %v %v", ok, err)
+	}
+	if ok, err := ValidateStruct(exampleStruct{Email: "foo"}); !ok || err != nil {
+		t.Errorf("Expected validation with default tag name to pass, got %v %v", ok, err)
+	}
+
+	if ok, err := vd.ValidateMap(map[string]interface{}{"email": "foo"}, map[string]interface{}{"email": "email"}); ok || err == nil {
+		t.Errorf("Expected map validation to fail, got %v %v", ok, err)
+	}
+}
+
+func TestNewRegistriesAreIsolated(t *testing.T) {
+	t.Parallel()
+
+	type exampleStruct struct {
+		Animal string `valid:"instanceOnlyAnimal(cat)"`
+		Code   string `valid:"instanceOnlyCode"`
+		Value  int    `valid:"instanceOnlyCustom"`
+	}
+
+	vd := New()
+	vd.RegisterParamTag("instanceOnlyAnimal", regexp.MustCompile(`^instanceOnlyAnimal\((\w+)\)$`), func(str string, params ...string) bool {
+		return str == params[0]
+	})
+	vd.RegisterTag("instanceOnlyCode", func(str string) bool {
+		return str == "code"
+	})
+	vd.RegisterCustomTypeTag("instanceOnlyCustom", func(i any, o any) bool {
+		return i.(int) > 0
+	})
+
+	valid := exampleStruct{Animal: "cat", Code: "code", Value: 1}
+	if ok, err := vd.ValidateStruct(valid); !ok || err != nil {
+		t.Errorf("Expected instance validation to pass, got %v %v", ok, err)
+	}
+	if ok, err := vd.ValidateStruct(exampleStruct{Animal: "dog", Code: "code", Value: 1}); ok || err == nil {
+		t.Errorf("Expected instance validation to fail, got %v %v", ok, err)
+	}
+	if ok, err := ValidateStruct(valid); ok || err == nil {
+		t.Errorf("Expected instance validators to be unknown to the package level functions, got %v %v", ok, err)
+	}
+	if _, ok := TagMap["instanceOnlyCode"]; ok {
+		t.Error("Expected RegisterTag not to modify the package level TagMap")
+	}
+}
+
+func TestErrorModes(t *testing.T) {
+	t.Parallel()
+
+	type signup struct {
+		Password string   `valid:"minstringlength(8),matches(\\d),!in(password1)"`
+		Email    string   `valid:"email"`
+		Tags     []string `valid:"minitems(3),unique"`
+	}
+	param := signup{Password: "pass", Email: "invalid", Tags: []string{"a", "a"}}
+
+	var tests = []struct {
+		mode     ErrorMode
+		expected int
+	}{
+		{FirstErrorPerField, 3},
+		{AllErrorsPerField, 5},
+		{FailFast, 1},
+	}
+	for _, test := range tests {
+		ok, err := New(WithErrorMode(test.mode)).ValidateStruct(param)
+		if ok || err == nil {
+			t.Errorf("mode %d: expected validation to fail", test.mode)
+			continue
+		}
+		if errs := flattenErrors(err); len(errs) != test.expected {
+			t.Errorf("mode %d: expected %d errors, got %d: %v", test.mode, test.expected, len(errs), err)
+		}
+	}
+
+	ok, err := ValidateStructCtx(ContextWithErrorMode(context.Background(), AllErrorsPerField), param)
+	if ok || len(flattenErrors(err)) != 5 {
+		t.Errorf("expected the error mode of the context to take precedence, got %v", err)
+	}
+	errs := ErrorsByField(err)
+	if errs["Password"] == "" || errs["Tags"] == "" {
+		t.Errorf("expected errors of Password and Tags, got %v", errs)
+	}
+}
+
+func TestAllErrorsPerFieldWithRequired(t *testing.T) {
+	t.Parallel()
+
+	type list struct {
+		Items []string `valid:"required,minitems(1)"`
+	}
+	ok, err := New(WithErrorMode(AllErrorsPerField)).ValidateStruct(list{})
+	if ok || len(flattenErrors(err)) != 2 {
+		t.Errorf("expected minitems and required errors, got %v", err)
+	}
+}
+
+func TestFieldNameFunc(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		ZipCode string `json:"zip_code" yaml:"zip" label:"ZIP code" valid:"numeric"`
+	}
+	type customer struct {
+		FullName  string            `json:"full_name" yaml:"name" label:"Full name" valid:"required"`
+		Home      address           `json:"home" yaml:"home"`
+		Addresses []address         `json:"addresses,omitempty" yaml:"addrs" valid:"dive"`
+		Tags      []string          `json:"-" yaml:"tags" valid:"dive,alpha"`
+		Phones    map[string]string `json:"phones" valid:"dive,numeric"`
+	}
+	param := customer{
+		Home:      address{"x"},
+		Addresses: []address{{"1"}, {"y"}},
+		Tags:      []string{"1"},
+		Phones:    map[string]string{"work": "z"},
+	}
+
+	var tests = []struct {
+		name     string
+		fn       FieldNameFunc
+		expected []string
+	}{
+		{"default", nil, []string{"full_name", "home.zip_code", "addresses.1.zip_code", "Tags.0", "phones.work"}},
+		{"json", FieldNameFromTag("json"), []string{"full_name", "home.zip_code", "addresses.1.zip_code", "Tags.0", "phones.work"}},
+		{"yaml", FieldNameFromTag("yaml"), []string{"name", "home.zip", "addrs.1.zip", "tags.0", "Phones.work"}},
+		{"label", func(f reflect.StructField) string { return f.Tag.Get("label") }, []string{"Full name", "Home.ZIP code", "Addresses.1.ZIP code", "Tags.0", "Phones.work"}},
+	}
+	for _, test := range tests {
+		_, err := New(WithFieldNameFunc(test.fn)).ValidateStruct(param)
+		var names []string
+		for _, e := range flattenErrors(err) {
+			e := e.(Error)
+			names = append(names, strings.Join(append(e.Path, e.Name), "."))
+		}
+		if !reflect.DeepEqual(names, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, names)
+		}
+	}
+}
+
+func TestFieldNamesInMixedNesting(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Zip string `json:"zip" valid:"numeric"`
+	}
+	type region struct {
+		Office   address            `json:"office"`
+		Branches []address          `json:"branches" valid:"dive"`
+		Depots   map[string]address `json:"depots" valid:"dive"`
+	}
+	type company struct {
+		Address address  `json:"address"`
+		Regions []region `json:"regions" valid:"dive"`
+		Head    region
+	}
+	bad := address{"x"}
+	param := company{
+		Address: bad,
+		Regions: []region{{Office: bad, Branches: []address{{"1"}, bad}, Depots: map[string]address{"north": bad}}},
+		Head:    region{Office: bad},
+	}
+	expected := []string{
+		"address.zip",
+		"regions.0.office.zip",
+		"regions.0.branches.1.zip",
+		"regions.0.depots.north.zip",
+		"Head.office.zip",
+	}
+	_, err := ValidateStruct(param)
+	var paths []string
+	for _, e := range flattenErrors(err) {
+		e := e.(Error)
+		paths = append(paths, strings.Join(append(e.Path, e.Name), "."))
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected %v, got %v", expected, paths)
+	}
+}
@@ -3,6 +3,7 @@ package govalidator
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/base64"
@@ -23,8 +24,6 @@ import (
 )
 
 var (
-	fieldsRequiredByDefault bool
-	nilPtrAllowedByRequired = false
 	// notNumberRegexp         = regexp.MustCompile("[^0-9]+")
 	whiteSpacesAndMinus = regexp.MustCompile(`[\s-]+`)
 	paramsRegexp        = regexp.MustCompile(`\(.*\)$`)
@@ -55,8 +54,10 @@ const rfc3339WithoutZone = "2006-01-02T15:04:05"
 //	type exampleStruct2 struct {
 //	    Name  string `valid:"-"`
 //	    Email string `valid:"email,optional"`
+//
+// It only affects the package level functions, use WithFieldsRequiredByDefault to configure an instance created by New.
 func SetFieldsRequiredByDefault(value bool) {
-	fieldsRequiredByDefault = value
+	defaultValidate.SetFieldsRequiredByDefault(value)
 }
 
 // SetNilPtrAllowedByRequired causes validation to pass for nil ptrs when a field is set to required.
@@ -68,8 +69,24 @@ func SetFieldsRequiredByDefault(value bool) {
 // With `Name` set to "", this will be considered invalid input and will cause a validation error.
 // With `Name` set to nil, this will be considered valid by validation.
 // By default this is disabled.
+// It only affects the package level functions, use WithNilPtrAllowedByRequired to configure an instance created by New.
 func SetNilPtrAllowedByRequired(value bool) {
-	nilPtrAllowedByRequired = value
+	defaultValidate.SetNilPtrAllowedByRequired(value)
+}
+
+// SetValidateMethods sets whether ValidateStruct calls the Validate methods of types implementing
+// Validatable or ValidatableWithContext. It is enabled by default, SetValidateMethods(false) disables it,
+// e.g. for types whose Validate method calls ValidateStruct on themselves without passing on its context.
+// It only affects the package level functions, use WithValidateMethods to configure an instance created by New.
+func SetValidateMethods(value bool) {
+	defaultValidate.SetValidateMethods(value)
+}
+
+// SetFieldNameFunc sets how struct fields are named in Error.Name and in every segment of Error.Path,
+// e.g. SetFieldNameFunc(FieldNameFromTag("json")). A nil func restores the default, see WithFieldNameFunc.
+// It only affects the package level functions, use WithFieldNameFunc to configure an instance created by New.
+func SetFieldNameFunc(fn FieldNameFunc) {
+	defaultValidate.SetFieldNameFunc(fn)
 }
 
 // IsEmail checks if the string is an email.
@@ -982,11 +999,33 @@ func toJSONName[T ~string](tag T) string {
 	return name
 }
 
+// renameField replaces the name of the field in the errors reported for it: the name of the
+// error itself, or the first segment of the path for errors reported for its elements.
+func renameField(err error, name, newName string) error {
+	switch err2 := err.(type) {
+	case Error:
+		if len(err2.Path) > 0 {
+			if err2.Path[0] == name {
+				err2.Path = append([]string{newName}, err2.Path[1:]...)
+			}
+		} else {
+			err2.Name = newName
+		}
+		return err2.rerender()
+	case Errors:
+		for i, err3 := range err2 {
+			err2[i] = renameField(err3, name, newName)
+		}
+		return err2
+	}
+	return err
+}
+
 func prependPathToErrors(err error, path string) error {
 	switch err2 := err.(type) {
 	case Error:
 		err2.Path = append([]string{path}, err2.Path...)
-		return err2
+		return err2.rerender()
 	case Errors:
 		errors := err2.Errors()
 		for i, err3 := range errors {
@@ -1009,6 +1048,26 @@ func ValidateArray[T any](array []T, iterator ConditionIterator[T]) bool {
 //
 //	map[string]interface{}{"name":"required,alpha","address":map[string]interface{}{"line1":"required,alphanum"}}
 func ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, error) {
+	return defaultValidate.ValidateMap(s, m)
+}
+
+// ValidateMapCtx is like ValidateMap, but hands ctx to the context-aware custom type validators
+// and stops early once ctx is done, returning ctx.Err().
+func ValidateMapCtx(ctx context.Context, s map[string]interface{}, m map[string]interface{}) (bool, error) {
+	return defaultValidate.ValidateMapCtx(ctx, s, m)
+}
+
+// ValidateMap use validation map for fields, see the package level ValidateMap.
+func (vd *Validate) ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, error) {
+	return vd.validateMap(context.Background(), s, m)
+}
+
+// ValidateMapCtx is the instance counterpart of the package level ValidateMapCtx.
+func (vd *Validate) ValidateMapCtx(ctx context.Context, s map[string]interface{}, m map[string]interface{}) (bool, error) {
+	return vd.validateMap(ctx, s, m)
+}
+
+func (vd *Validate) validateMap(ctx context.Context, s map[string]interface{}, m map[string]interface{}) (bool, error) {
 	if s == nil {
 		return true, nil
 	}
@@ -1017,7 +1076,40 @@ func ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, erro
 	var errs Errors
 	var index int
 	val := reflect.ValueOf(s)
-	for key, value := range s {
+	c := vd.cache()
+	groups := groupsFrom(ctx)
+	failFast := vd.errorModeFor(ctx) == FailFast
+	requiredResult := true
+	for _, key := range mapKeys(s, m) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		if failFast && len(errs) > 0 {
+			break
+		}
+		value, present := s[key]
+		if !present {
+			// checks required keys
+			schema, ok := m[key].(string)
+			if !ok {
+				continue
+			}
+			fp := &fieldPlan{name: key, tagPlan: vd.tagPlanFor(c, schema, groups)}
+			required := fp.required
+			if required == nil {
+				required = requiredByCondition(fp, val)
+			}
+			if required != nil {
+				requiredResult = false
+				if required.message != "" {
+					err = required.messageError(key, reflect.Value{}, "", required.name)
+				} else {
+					err = required.newError(key, reflect.Value{}, fmt.Errorf("required field missing"), false)
+				}
+				errs = append(errs, err)
+			}
+			continue
+		}
 		presentResult := true
 		validator, ok := m[key]
 		if !ok {
@@ -1041,7 +1133,7 @@ func ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, erro
 				err = prependPathToErrors(err, key)
 				errs = append(errs, err)
 			} else {
-				mapResult, err = ValidateMap(v, subValidator)
+				mapResult, err = vd.validateMap(ctx, v, subValidator)
 				if err != nil {
 					mapResult = false
 					err = prependPathToErrors(err, key)
@@ -1053,20 +1145,16 @@ func ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, erro
 				(valueField.Kind() == reflect.Ptr && valueField.Elem().Kind() == reflect.Struct)) &&
 				subValidator != "-" {
 				var err error
-				structResult, err = ValidateStruct(valueField.Interface())
+				structResult, err = vd.validateStruct(ctx, valueField.Interface())
 				if err != nil {
 					err = prependPathToErrors(err, key)
 					errs = append(errs, err)
 				}
 			}
-			resultField, err = typeCheck(valueField, reflect.StructField{
-				Name:      key,
-				PkgPath:   "",
-				Type:      val.Type(),
-				Tag:       reflect.StructTag(fmt.Sprintf("%s:%q", tagName, subValidator)),
-				Offset:    0,
-				Index:     []int{index},
-				Anonymous: false,
+			resultField, err = vd.typeCheck(ctx, valueField, &fieldPlan{
+				index:   index,
+				name:    key,
+				tagPlan: vd.tagPlanFor(c, subValidator, groups),
 			}, val, nil)
 			if err != nil {
 				errs = append(errs, err)
@@ -1082,35 +1170,40 @@ func ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, erro
 		result = result && presentResult && typeResult && resultField && structResult && mapResult
 		index++
 	}
-	// checks required keys
-	requiredResult := true
-	for key, value := range m {
-		if schema, ok := value.(string); ok {
-			tags := parseTagIntoMap(schema)
-			if required, ok := tags["required"]; ok {
-				if _, ok := s[key]; !ok {
-					requiredResult = false
-					if required.customErrorMessage != "" {
-						err = Error{key, fmt.Errorf(required.customErrorMessage), true, "required", []string{}}
-					} else {
-						err = Error{key, fmt.Errorf("required field missing"), false, "required", []string{}}
-					}
-					errs = append(errs, err)
-				}
-			}
-		}
-	}
 
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
 	if len(errs) > 0 {
-		err = errs
+		err = vd.ordered(errs)
 	}
 	return result && requiredResult, err
 }
 
 // ValidateStruct use tags for fields.
 // result will be equal to `false` if there are any errors.
-// todo currently there is no guarantee that errors will be returned in predictable order (tests may to fail)
+// Errors are reported in the order of the fields, see ErrorOrder.
 func ValidateStruct[T any](s T) (bool, error) {
+	return defaultValidate.ValidateStruct(s)
+}
+
+// ValidateStructCtx is like ValidateStruct, but hands ctx to the context-aware custom type validators
+// and stops early once ctx is done, returning ctx.Err().
+func ValidateStructCtx[T any](ctx context.Context, s T) (bool, error) {
+	return defaultValidate.ValidateStructCtx(ctx, s)
+}
+
+// ValidateStruct use tags for fields, see the package level ValidateStruct.
+func (vd *Validate) ValidateStruct(s any) (bool, error) {
+	return vd.validateStruct(context.Background(), s)
+}
+
+// ValidateStructCtx is the instance counterpart of the package level ValidateStructCtx.
+func (vd *Validate) ValidateStructCtx(ctx context.Context, s any) (bool, error) {
+	return vd.validateStruct(ctx, s)
+}
+
+func (vd *Validate) validateStruct(ctx context.Context, s any) (bool, error) {
 	if reflect.ValueOf(s) == reflect.ValueOf(nil) {
 		return true, nil
 	}
@@ -1124,12 +1217,26 @@ func ValidateStruct[T any](s T) (bool, error) {
 	if val.Kind() != reflect.Struct {
 		return false, fmt.Errorf("function only accepts structs; got %s", val.Kind())
 	}
+	ctx, self := revalidating(ctx, val)
 	var errs Errors
-	for i := 0; i < val.NumField(); i++ {
-		valueField := val.Field(i)
-		typeField := val.Type().Field(i)
-		if typeField.PkgPath != "" {
-			continue // Private field
+	failFast := vd.errorModeFor(ctx) == FailFast
+	filter := pathFilterFrom(ctx)
+	for _, fp := range vd.structPlanFor(val.Type(), groupsFrom(ctx)).fields {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		sub, own, ok := filter.field(fp.name)
+		if !ok {
+			continue
+		}
+		fctx := filterContext(ctx, filter, sub)
+		valueField := val.Field(fp.index)
+		if own {
+			if err := applyDefault(valueField, fp); err != nil {
+				errs = append(errs, err)
+				result = false
+				continue
+			}
 		}
 		structResult := true
 		if valueField.Kind() == reflect.Interface {
@@ -1137,51 +1244,76 @@ func ValidateStruct[T any](s T) (bool, error) {
 		}
 		if (valueField.Kind() == reflect.Struct ||
 			(valueField.Kind() == reflect.Ptr && valueField.Elem().Kind() == reflect.Struct)) &&
-			typeField.Tag.Get(tagName) != "-" {
+			fp.tag != "-" {
 			var err error
-			structResult, err = ValidateStruct(valueField.Interface())
+			nested := valueField.Interface()
+			if valueField.Kind() == reflect.Struct && valueField.CanAddr() {
+				// fields of a struct passed by pointer can be set, e.g. to their defaults
+				nested = valueField.Addr().Interface()
+			}
+			structResult, err = vd.validateStruct(fctx, nested)
 			if err != nil {
-				err = prependPathToErrors(err, typeField.Name)
+				err = prependPathToErrors(err, fp.pathName)
 				errs = append(errs, err)
+				if failFast {
+					break
+				}
 			}
 		}
-		resultField, err2 := typeCheck(valueField, typeField, val, nil)
-		if err2 != nil {
-
-			// Replace structure name with JSON name if there is a tag on the variable
-			jsonTag := toJSONName(typeField.Tag.Get("json"))
-			if jsonTag != "" {
-				switch jsonError := err2.(type) {
-				case Error:
-					jsonError.Name = jsonTag
-					err2 = jsonError
-				case Errors:
-					for i2, err3 := range jsonError {
-						//revive:disable
-						switch customErr := err3.(type) {
-						case Error:
-							customErr.Name = jsonTag
-							jsonError[i2] = customErr
-						}
-						//revive:enable
+		resultField, err2 := true, error(nil)
+		if own {
+			resultField, err2 = vd.typeCheck(fctx, valueField, fp, val, nil)
+			if indirectValue(valueField).Kind() != reflect.Struct && fp.tag != "-" {
+				// structs call their Validate method themselves
+				if err3 := vd.callValidatable(fctx, valueField, fp.name); err3 != nil {
+					resultField = false
+					if err2 == nil {
+						err2 = err3
+					} else {
+						err2 = appendErrors(appendErrors(nil, err2), err3)
 					}
-
-					err2 = jsonError
 				}
 			}
+		} else if elems := indirectValue(valueField); isCollectionKind(elems.Kind()) {
+			// only fields below the elements were asked for
+			resultField, err2 = vd.diveCheck(fctx, elems, fp, val)
+		}
+		if err2 != nil {
+			// Replace the Go name with the name given by the field name resolver, the JSON name by default
+			if fp.errorName != "" {
+				err2 = renameField(err2, fp.name, fp.errorName)
+			}
 
 			errs = append(errs, err2)
 		}
 		result = result && resultField && structResult
+		if failFast && len(errs) > 0 {
+			break
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	// the invariants of a partially validated struct may involve fields which were not asked for
+	if filter == nil && !self && !(failFast && len(errs) > 0) {
+		if err := vd.callValidatable(ctx, val, ""); err != nil {
+			result = false
+			errs = appendErrors(errs, err)
+		}
 	}
 	if len(errs) > 0 {
-		err = errs
+		err = vd.ordered(errs)
 	}
 	return result, err
 }
 
 // ValidateStructAsync performs async validation of the struct and returns results through the channels
 func ValidateStructAsync[T any](s T) (<-chan bool, <-chan error) {
+	return defaultValidate.ValidateStructAsync(s)
+}
+
+// ValidateStructAsync performs async validation of the struct and returns results through the channels
+func (vd *Validate) ValidateStructAsync(s any) (<-chan bool, <-chan error) {
 	res := make(chan bool)
 	errors := make(chan error)
 
@@ -1189,7 +1321,7 @@ func ValidateStructAsync[T any](s T) (<-chan bool, <-chan error) {
 		defer close(res)
 		defer close(errors)
 
-		isValid, isFailed := ValidateStruct(s)
+		isValid, isFailed := vd.ValidateStruct(s)
 
 		res <- isValid
 		errors <- isFailed
@@ -1200,6 +1332,11 @@ func ValidateStructAsync[T any](s T) (<-chan bool, <-chan error) {
 
 // ValidateMapAsync performs async validation of the map and returns results through the channels
 func ValidateMapAsync(s map[string]interface{}, m map[string]interface{}) (<-chan bool, <-chan error) {
+	return defaultValidate.ValidateMapAsync(s, m)
+}
+
+// ValidateMapAsync performs async validation of the map and returns results through the channels
+func (vd *Validate) ValidateMapAsync(s map[string]interface{}, m map[string]interface{}) (<-chan bool, <-chan error) {
 	res := make(chan bool)
 	errors := make(chan error)
 
@@ -1207,7 +1344,7 @@ func ValidateMapAsync(s map[string]interface{}, m map[string]interface{}) (<-cha
 		defer close(res)
 		defer close(errors)
 
-		isValid, isFailed := ValidateMap(s, m)
+		isValid, isFailed := vd.ValidateMap(s, m)
 
 		res <- isValid
 		errors <- isFailed
@@ -1216,27 +1353,6 @@ func ValidateMapAsync(s map[string]interface{}, m map[string]interface{}) (<-cha
 	return res, errors
 }
 
-// parseTagIntoMap parses a struct tag `valid:required~Some error message,length(2|3)` into map[string]string{"required": "Some error message", "length(2|3)": ""}
-func parseTagIntoMap(tag string) tagOptionsMap {
-	optionsMap := make(tagOptionsMap)
-	options := strings.Split(tag, ",")
-
-	for i, option := range options {
-		option = strings.TrimSpace(option)
-
-		validationOptions := strings.Split(option, "~")
-		if !isValidTag(validationOptions[0]) {
-			continue
-		}
-		if len(validationOptions) == 2 {
-			optionsMap[validationOptions[0]] = tagOption{validationOptions[0], validationOptions[1], i}
-		} else {
-			optionsMap[validationOptions[0]] = tagOption{validationOptions[0], "", i}
-		}
-	}
-	return optionsMap
-}
-
 func isValidTag(s string) bool {
 	if s == "" {
 		return false
@@ -1399,6 +1515,18 @@ func Range[T ~string](str T, params ...T) bool {
 	return false
 }
 
+// isInParams is the validator of the in tag, which receives the allowed values as parsed from the tag,
+// e.g. ["a|b", "c"] for `in('a|b'|c)`.
+func isInParams(str string, params ...string) bool {
+	return IsIn(str, params...)
+}
+
+// matchesParams is the validator of the matches tag. The pattern is handed over split at its |, which
+// are alternations of the regular expression.
+func matchesParams(str string, params ...string) bool {
+	return Matches(str, strings.Join(params, "|"))
+}
+
 // IsInRaw checks if string is in list of allowed values
 func IsInRaw[T ~string](str T, params ...T) bool {
 	if len(params) == 1 {
@@ -1423,137 +1551,240 @@ func IsIn[T ~string](str T, params ...string) bool {
 	return false
 }
 
-func checkRequired(v reflect.Value, t reflect.StructField, options tagOptionsMap) (bool, error) {
-	if nilPtrAllowedByRequired {
+// requiredRule reports fields which are required by default, see SetFieldsRequiredByDefault.
+var requiredRule = &rule{spec: "required", validator: "required", name: "required", kind: ruleRequired, code: CodeRequired}
+
+func (vd *Validate) checkRequired(v reflect.Value, f *fieldPlan, o reflect.Value) (bool, error) {
+	if vd.nilPtrAllowedByRequired {
 		k := v.Kind()
 		if (k == reflect.Ptr || k == reflect.Interface) && v.IsNil() {
 			return true, nil
 		}
 	}
 
-	if requiredOption, isRequired := options["required"]; isRequired {
-		if len(requiredOption.customErrorMessage) > 0 {
-			return false, Error{t.Name, fmt.Errorf(requiredOption.customErrorMessage), true, "required", []string{}}
+	if requiredOption := f.required; requiredOption != nil {
+		if len(requiredOption.message) > 0 {
+			return false, requiredOption.messageError(f.name, v, "", requiredOption.name)
+		}
+		return false, requiredOption.newError(f.name, v, fmt.Errorf("non zero value required"), false)
+	} else if r := requiredByCondition(f, o); r != nil {
+		if len(r.message) > 0 {
+			return false, r.messageError(f.name, v, "", r.name)
 		}
-		return false, Error{t.Name, fmt.Errorf("non zero value required"), false, "required", []string{}}
-	} else if _, isOptional := options["optional"]; fieldsRequiredByDefault && !isOptional {
-		return false, Error{t.Name, fmt.Errorf("Missing required field"), false, "required", []string{}}
+		return false, r.newError(f.name, v, fmt.Errorf("non zero value required"), false)
+	} else if vd.fieldsRequiredByDefault && !f.optional && !f.conditional {
+		return false, requiredRule.newError(f.name, v, fmt.Errorf("Missing required field"), false)
 	}
 	// not required and empty is valid
 	return true, nil
 }
 
+// ruleError builds the error reported when the value v, formatted as str, does not satisfy the rule r.
+func ruleError(f *fieldPlan, r *rule, v reflect.Value, str string) Error {
+	if len(r.message) > 0 {
+		return r.messageError(f.name, v, str, r.validator)
+	}
+	if r.negate {
+		return r.newError(f.name, v, fmt.Errorf("%s does validate as %s", str, r.validator), false)
+	}
+	return r.newError(f.name, v, fmt.Errorf("%s does not validate as %s", str, r.validator), false)
+}
+
+// newError builds the Error of the rule for the value v of the field name, v is invalid for missing values.
+func (r *rule) newError(name string, v reflect.Value, err error, custom bool) Error {
+	e := Error{Name: name, Err: err, CustomErrorMessageExists: custom, Validator: r.name, Path: []string{},
+		Param: r.tagParams, Code: r.code}
+	if v.IsValid() {
+		e.Kind = v.Kind()
+		if v.CanInterface() {
+			e.Value = v.Interface()
+		}
+	}
+	return e
+}
+
+// messageError builds the Error of r with its custom message for the value v, which is formatted as str
+// for the %s verbs of legacy messages along with validator.
+func (r *rule) messageError(name string, v reflect.Value, str, validator string) Error {
+	e := r.newError(name, v, nil, true)
+	e.Err = customMessageError(r.message, e, str, validator)
+	return e
+}
+
 // revive:disable
-func typeCheck(v reflect.Value, t reflect.StructField, o reflect.Value, options tagOptionsMap) (isValid bool, resultErr error) {
+// typeCheck validates v with the rules of the field plan f, o is the struct or map v belongs to.
+// applied records which rules could be applied to the value, it is nil for the field itself
+// and shared with the recursive calls for its elements.
+func (vd *Validate) typeCheck(ctx context.Context, v reflect.Value, f *fieldPlan, o reflect.Value, applied []bool) (isValid bool, resultErr error) {
 	if !v.IsValid() {
 		return false, nil
 	}
 
-	tag := t.Tag.Get(tagName)
-
 	// checks if the field should be ignored
-	switch tag {
+	switch f.tag {
 	case "":
 		if v.Kind() != reflect.Slice && v.Kind() != reflect.Map {
-			if !fieldsRequiredByDefault {
+			if !vd.fieldsRequiredByDefault {
 				return true, nil
 			}
-			return false, Error{t.Name, fmt.Errorf("All fields are required to at least have one validation defined"), false, "required", []string{}}
+			return false, requiredRule.newError(f.name, v, fmt.Errorf("All fields are required to at least have one validation defined"), false)
 		}
 	case "-":
 		return true, nil
 	}
+	if f.err != nil {
+		return false, Error{Name: f.name, Err: f.err, Path: []string{}, Code: CodeInvalidTag}
+	}
 
 	isRootType := false
-	if options == nil {
+	if applied == nil {
 		isRootType = true
-		options = parseTagIntoMap(tag)
+		applied = make([]bool, len(f.rules))
+	}
+
+	// report returns err when validation of the field stops at its first failing rule,
+	// otherwise it collects err and the remaining rules are applied as well
+	allRules := vd.errorModeFor(ctx) == AllErrorsPerField
+	var ruleErrors Errors
+	report := func(err error) error {
+		if err == nil || !allRules {
+			return err
+		}
+		ruleErrors = appendErrors(ruleErrors, err)
+		return nil
+	}
+	defer func() {
+		if len(ruleErrors) > 0 {
+			if resultErr != nil {
+				ruleErrors = appendErrors(ruleErrors, resultErr)
+			}
+			isValid, resultErr = false, ruleErrors
+		}
+	}()
+
+	if isCollectionKind(v.Kind()) {
+		if err := checkCollection(v, f, applied, report); err != nil {
+			return false, err
+		}
 	}
 
 	if isEmptyValue(v) {
-		// an empty value is not validated, checks only required
-		isValid, resultErr = checkRequired(v, t, options)
-		for key := range options {
-			delete(options, key)
+		// an empty value is not validated, checks only required and the comparisons with other fields that are set
+		for i := range applied {
+			applied[i] = true
+		}
+		if ok, err := vd.checkRequired(v, f, o); !ok || err != nil || isCollectionKind(v.Kind()) {
+			return ok, err
+		}
+		for _, r := range f.rules {
+			if r.kind != ruleField || !fieldPresent(o, r.params[0]) {
+				continue
+			}
+			if err := report(checkField(v, f, o, r)); err != nil {
+				return false, err
+			}
 		}
-		return isValid, resultErr
+		return true, nil
 	}
 
 	var customTypeErrors Errors
-	optionsOrder := options.orderedKeys()
-	for _, validatorName := range optionsOrder {
-		validatorStruct := options[validatorName]
-		if validatefunc, ok := CustomTypeTagMap.Get(validatorName); ok {
-			delete(options, validatorName)
-
-			if result := validatefunc(v.Interface(), o.Interface()); !result {
-				if len(validatorStruct.customErrorMessage) > 0 {
-					customTypeErrors = append(customTypeErrors, Error{Name: t.Name, Err: TruncatingErrorf(validatorStruct.customErrorMessage, fmt.Sprint(v), validatorName), CustomErrorMessageExists: true, Validator: stripParams(validatorName)})
-					continue
-				}
-				customTypeErrors = append(customTypeErrors, Error{Name: t.Name, Err: fmt.Errorf("%s does not validate as %s", fmt.Sprint(v), validatorName), CustomErrorMessageExists: false, Validator: stripParams(validatorName)})
+	for i, r := range f.rules {
+		if r.kind != ruleCustom || applied[i] {
+			continue
+		}
+		applied[i] = true
+		if result := r.customFn(ctx, v.Interface(), o.Interface()); !result {
+			if len(r.message) > 0 {
+				customTypeErrors = append(customTypeErrors, r.messageError(f.name, v, fmt.Sprint(v), r.spec))
+				continue
 			}
+			customTypeErrors = append(customTypeErrors, r.newError(f.name, v, fmt.Errorf("%s does not validate as %s", fmt.Sprint(v), r.spec), false))
 		}
 	}
 
 	if len(customTypeErrors.Errors()) > 0 {
-		return false, customTypeErrors
+		if err := report(customTypeErrors); err != nil {
+			return false, err
+		}
+	}
+
+	for i, r := range f.rules {
+		if applied[i] {
+			continue
+		}
+		switch r.kind {
+		case ruleField:
+			if isCollectionKind(v.Kind()) {
+				// the elements of the collection are compared with the other field
+				continue
+			}
+			applied[i] = true
+			if err := report(checkField(v, f, o, r)); err != nil {
+				return false, err
+			}
+		case ruleExcludedIf:
+			applied[i] = true
+			if r.conditionFn(o, r.params) {
+				var err error = r.newError(f.name, v, fmt.Errorf("value must be empty"), false)
+				if len(r.message) > 0 {
+					err = r.messageError(f.name, v, fmt.Sprint(v), r.validator)
+				}
+				if err = report(err); err != nil {
+					return false, err
+				}
+			}
+		}
 	}
 
 	if isRootType {
 		// Ensure that we've checked the value by all specified validators before report that the value is valid
 		defer func() {
-			delete(options, "optional")
-			delete(options, "required")
-
-			if isValid && resultErr == nil && len(options) != 0 {
-				optionsOrder := options.orderedKeys()
-				for _, validator := range optionsOrder {
+			if isValid && resultErr == nil {
+				for i, r := range f.rules {
+					if applied[i] || r.kind == ruleRequired || r.kind == ruleOptional || r.kind == ruleRequiredIf || r.kind == ruleDefault {
+						continue
+					}
 					isValid = false
-					resultErr = Error{t.Name, fmt.Errorf(
-						"The following validator is invalid or can't be applied to the field: %q", validator), false, stripParams(validator), []string{}}
+					e := r.newError(f.name, v, fmt.Errorf(
+						"The following validator is invalid or can't be applied to the field: %q", r.spec), false)
+					e.Code = CodeInvalidValidator
+					resultErr = e
 					return
 				}
 			}
 		}()
 	}
 
-	for _, validatorSpec := range optionsOrder {
-		validatorStruct := options[validatorSpec]
-		var negate bool
-		validator := validatorSpec
-		customMsgExists := len(validatorStruct.customErrorMessage) > 0
-
-		// checks whether the tag looks like '!something' or 'something'
-		if validator[0] == '!' {
-			validator = validator[1:]
-			negate = true
+	for i, r := range f.rules {
+		if r = vd.resolved(r); r.kind != ruleInterfaceParam || applied[i] {
+			continue
 		}
-
-		// checks for interface param validators
-		for key, value := range InterfaceParamTagRegexMap {
-			ps := value.FindStringSubmatch(validator)
-			if len(ps) == 0 {
-				continue
-			}
-
-			validatefunc, ok := InterfaceParamTagMap[key]
-			if !ok {
-				continue
+		fn, ok := vd.interfaceFunc(r)
+		if !ok {
+			// the validator was removed from the registry since the plan was built
+			continue
+		}
+		applied[i] = true
+		if result := fn(v, r.params...); result == r.negate {
+			if err := report(ruleError(f, r, v, fmt.Sprint(v))); err != nil {
+				return false, err
 			}
+		}
+	}
 
-			delete(options, validatorSpec)
+	for i, r := range f.rules {
+		if r.kind != ruleExpr || applied[i] {
+			continue
+		}
+		applied[i] = true
+		if err := report(vd.checkExpression(ctx, v, f, o, r)); err != nil {
+			return false, err
+		}
+	}
 
-			field := fmt.Sprint(v)
-			if result := validatefunc(v, ps[1:]...); (!result && !negate) || (result && negate) {
-				if customMsgExists {
-					return false, Error{t.Name, TruncatingErrorf(validatorStruct.customErrorMessage, field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-				}
-				if negate {
-					return false, Error{t.Name, fmt.Errorf("%s does validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-				}
-				return false, Error{t.Name, fmt.Errorf("%s does not validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-			}
+	if isTimeType(v.Type()) {
+		if err := vd.checkTime(v, f, applied, report); err != nil {
+			return false, err
 		}
 	}
 
@@ -1564,100 +1795,97 @@ func typeCheck(v reflect.Value, t reflect.StructField, o reflect.Value, options
 		reflect.Float32, reflect.Float64,
 		reflect.String:
 		// for each tag option checks the map of validator functions
-		for _, validatorSpec := range optionsOrder {
-			validatorStruct := options[validatorSpec]
-			var negate bool
-			validator := validatorSpec
-			customMsgExists := len(validatorStruct.customErrorMessage) > 0
-
-			// checks whether the tag looks like '!something' or 'something'
-			if validator[0] == '!' {
-				validator = validator[1:]
-				negate = true
+		for i, r := range f.rules {
+			r = vd.resolved(r)
+			if r.kind != ruleParam && r.kind != ruleTag && r.kind != ruleNumber {
+				continue
 			}
+			applied[i] = true
 
-			// checks for param validators
-			for key, value := range ParamTagRegexMap {
-				ps := value.FindStringSubmatch(validator)
-				if len(ps) == 0 {
-					continue
+			if r.numberFn != nil && isNumberKind(v.Kind()) {
+				// numbers are compared natively instead of through their string representation
+				if result := r.numberFn(v, r.numberParams); result == r.negate {
+					if err := report(ruleError(f, r, v, fmt.Sprint(v))); err != nil {
+						return false, err
+					}
 				}
+				continue
+			}
 
-				validatefunc, ok := ParamTagMap[key]
-				if !ok {
-					continue
+			switch v.Kind() {
+			case reflect.String,
+				reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+				reflect.Float32, reflect.Float64:
+				if r.kind == ruleNumber {
+					return false, unsupportedKindError(f, r, v)
 				}
-
-				delete(options, validatorSpec)
-
-				switch v.Kind() {
-				case reflect.String,
-					reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-					reflect.Float32, reflect.Float64:
-
-					field := fmt.Sprint(v) // make value into string, then validate with regex
-					if result := validatefunc(field, ps[1:]...); (!result && !negate) || (result && negate) {
-						if customMsgExists {
-							return false, Error{t.Name, TruncatingErrorf(validatorStruct.customErrorMessage, field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-						}
-						if negate {
-							return false, Error{t.Name, fmt.Errorf("%s does validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-						}
-						return false, Error{t.Name, fmt.Errorf("%s does not validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
+				field := fmt.Sprint(v) // make value into string, then validate with regex
+				var result bool
+				if r.kind == ruleParam {
+					fn, ok := vd.paramFunc(r)
+					if !ok {
+						applied[i] = false
+						continue
+					}
+					result = fn(field, r.params...)
+				} else {
+					fn, ok := vd.tagFunc(r)
+					if !ok {
+						applied[i] = false
+						continue
 					}
-				default:
-					// type not yet supported, fail
-					return false, Error{t.Name, fmt.Errorf("Validator %s doesn't support kind %s", validator, v.Kind()), false, stripParams(validatorSpec), []string{}}
+					result = fn(field)
 				}
-			}
-
-			if validatefunc, ok := TagMap[validator]; ok {
-				delete(options, validatorSpec)
-
-				switch v.Kind() {
-				case reflect.String,
-					reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-					reflect.Float32, reflect.Float64:
-					field := fmt.Sprint(v) // make value into string, then validate with regex
-					if result := validatefunc(field); !result && !negate || result && negate {
-						if customMsgExists {
-							return false, Error{t.Name, TruncatingErrorf(validatorStruct.customErrorMessage, field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-						}
-						if negate {
-							return false, Error{t.Name, fmt.Errorf("%s does validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-						}
-						return false, Error{t.Name, fmt.Errorf("%s does not validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
+				if result == r.negate {
+					if err := report(ruleError(f, r, v, field)); err != nil {
+						return false, err
 					}
-				default:
-					// Not Yet Supported Types (Fail here!)
-					err := fmt.Errorf("Validator %s doesn't support kind %s for value %v", validator, v.Kind(), v)
-					return false, Error{t.Name, err, false, stripParams(validatorSpec), []string{}}
 				}
+			default:
+				// Not Yet Supported Types (Fail here!)
+				if r.kind == ruleParam || r.kind == ruleNumber {
+					return false, unsupportedKindError(f, r, v)
+				}
+				e := r.newError(f.name, v, fmt.Errorf("Validator %s doesn't support kind %s for value %v", r.validator, v.Kind(), v), false)
+				e.Code = CodeUnsupportedKind
+				return false, e
 			}
 		}
+		if f.dive != nil {
+			return false, invalidDiveError(f)
+		}
 		return true, nil
 	case reflect.Map:
+		if f.dive != nil {
+			return vd.diveCheck(ctx, v, f, o)
+		}
 		if v.Type().Key().Kind() != reflect.String {
 			return false, &UnsupportedTypeError{v.Type()}
 		}
+		base := append([]bool(nil), applied...)
 		var sv stringValues
 		sv = v.MapKeys()
 		sort.Sort(sv)
 		result := true
+		filter := pathFilterFrom(ctx)
 		for i, k := range sv {
+			sub, _, ok := filter.field(k.String())
+			if !ok {
+				continue
+			}
+			ectx := filterContext(ctx, filter, sub)
 			var resultItem bool
 			var err error
 			if v.MapIndex(k).Kind() != reflect.Struct {
-				resultItem, err = typeCheck(v.MapIndex(k), t, o, options)
+				resultItem, err = vd.typeCheckElem(ectx, v.MapIndex(k), f, o, base, applied)
 				if err != nil {
 					return false, err
 				}
 			} else {
-				resultItem, err = ValidateStruct(v.MapIndex(k).Interface())
+				resultItem, err = vd.validateStruct(ectx, v.MapIndex(k).Interface())
 				if err != nil {
-					err = prependPathToErrors(err, t.Name+"."+sv[i].Interface().(string))
+					err = prependPathToErrors(prependPathToErrors(err, sv[i].Interface().(string)), f.name)
 					return false, err
 				}
 			}
@@ -1665,19 +1893,29 @@ func typeCheck(v reflect.Value, t reflect.StructField, o reflect.Value, options
 		}
 		return result, nil
 	case reflect.Slice, reflect.Array:
+		if f.dive != nil {
+			return vd.diveCheck(ctx, v, f, o)
+		}
 		result := true
+		base := append([]bool(nil), applied...)
+		filter := pathFilterFrom(ctx)
 		for i := 0; i < v.Len(); i++ {
+			sub, _, ok := filter.field(strconv.Itoa(i))
+			if !ok {
+				continue
+			}
+			ectx := filterContext(ctx, filter, sub)
 			var resultItem bool
 			var err error
 			if v.Index(i).Kind() != reflect.Struct {
-				resultItem, err = typeCheck(v.Index(i), t, o, options)
+				resultItem, err = vd.typeCheckElem(ectx, v.Index(i), f, o, base, applied)
 				if err != nil {
 					return false, err
 				}
 			} else {
-				resultItem, err = ValidateStruct(v.Index(i).Interface())
+				resultItem, err = vd.validateStruct(ectx, v.Index(i).Interface())
 				if err != nil {
-					err = prependPathToErrors(err, t.Name+"."+strconv.Itoa(i))
+					err = prependPathToErrors(prependPathToErrors(err, strconv.Itoa(i)), f.name)
 					return false, err
 				}
 			}
@@ -1689,20 +1927,47 @@ func typeCheck(v reflect.Value, t reflect.StructField, o reflect.Value, options
 		if v.IsNil() {
 			return true, nil
 		}
-		return ValidateStruct(v.Interface())
+		return vd.validateStruct(ctx, v.Interface())
 	case reflect.Ptr:
 		// If the value is a pointer then checks its element
 		if v.IsNil() {
 			return true, nil
 		}
-		return typeCheck(v.Elem(), t, o, options)
+		return vd.typeCheck(ctx, v.Elem(), f, o, applied)
 	case reflect.Struct:
+		if f.dive != nil {
+			return false, invalidDiveError(f)
+		}
 		return true, nil
 	default:
 		return false, &UnsupportedTypeError{v.Type()}
 	}
 }
 
+// typeCheckElem validates an element of a collection without dive marker with the rules of f. Every element
+// starts from the rules applied to the collection itself, given by base, so that rules like eqfield are
+// applied to each element, and the rules applied to any element are recorded as applied to the field.
+func (vd *Validate) typeCheckElem(ctx context.Context, e reflect.Value, f *fieldPlan, o reflect.Value, base, applied []bool) (bool, error) {
+	elemApplied := append([]bool(nil), base...)
+	result, err := vd.typeCheck(ctx, e, f, o, elemApplied)
+	for i, a := range elemApplied {
+		applied[i] = applied[i] || a
+	}
+	return result, err
+}
+
+// unsupportedKindError reports that the validator of r cannot be applied to the kind of v.
+func unsupportedKindError(f *fieldPlan, r *rule, v reflect.Value) Error {
+	e := r.newError(f.name, v, fmt.Errorf("Validator %s doesn't support kind %s", r.validator, v.Kind()), false)
+	e.Code = CodeUnsupportedKind
+	return e
+}
+
+func invalidDiveError(f *fieldPlan) error {
+	return Error{Name: f.name, Err: fmt.Errorf("The following validator is invalid or can't be applied to the field: %q", diveTag),
+		Validator: diveTag, Path: []string{}, Code: CodeInvalidValidator}
+}
+
 func stripParams(validatorString string) string {
 	return paramsRegexp.ReplaceAllString(validatorString, "")
 }
@@ -1741,6 +2006,7 @@ func ErrorByField(e error, field string) string {
 
 // ErrorsByField returns map of errors of the struct validated
 // by ValidateStruct or empty map if there are no errors.
+// Errors are keyed by the name of the field only, see ErrorsByPath for errors keyed by their full path.
 func ErrorsByField(e error) map[string]string {
 	m := make(map[string]string)
 	if e == nil {
@@ -1768,6 +2034,11 @@ func (e *UnsupportedTypeError) Error() string {
 	return "validator: unsupported type: " + e.Type.String()
 }
 
+// Is reports whether target is ErrUnsupportedType.
+func (e *UnsupportedTypeError) Is(target error) bool {
+	return target == ErrUnsupportedType
+}
+
 func (sv stringValues) Len() int { return len(sv) }
 
 func (sv stringValues) Swap(i, j int) { sv[i], sv[j] = sv[j], sv[i] }
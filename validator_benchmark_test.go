@@ -0,0 +1,67 @@
+package govalidator
+
+import "testing"
+
+type benchmarkAddress struct {
+	Street  string `valid:"required,printableascii,maxstringlength(64)"`
+	ZipCode string `valid:"numeric,stringlength(5|10)"`
+}
+
+type benchmarkUser struct {
+	Name      string             `valid:"required,alpha,minstringlength(2)"`
+	Email     string             `valid:"required,email"`
+	Website   string             `valid:"url,optional"`
+	Role      string             `valid:"in(admin|user|guest)"`
+	Age       int                `valid:"numeric"`
+	Address   *benchmarkAddress  `valid:"required"`
+	Addresses []benchmarkAddress `valid:"-"`
+}
+
+var benchmarkUserValue = benchmarkUser{
+	Name:    "John",
+	Email:   "john@example.com",
+	Website: "https://example.com",
+	Role:    "admin",
+	Age:     42,
+	Address: &benchmarkAddress{Street: "Main street", ZipCode: "12345"},
+}
+
+func BenchmarkValidateStruct(b *testing.B) {
+	vd := New()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_, _ = vd.ValidateStruct(benchmarkUserValue)
+	}
+}
+
+// BenchmarkValidateStructUncached rebuilds the validation plans on every call,
+// which is what ValidateStruct did before plans were cached.
+func BenchmarkValidateStructUncached(b *testing.B) {
+	vd := New()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		vd.resetPlans()
+		_, _ = vd.ValidateStruct(benchmarkUserValue)
+	}
+}
+
+func BenchmarkValidateMap(b *testing.B) {
+	vd := New()
+	schema := map[string]interface{}{
+		"name":  "required,alpha",
+		"email": "required,email",
+		"role":  "in(admin|user|guest)",
+	}
+	value := map[string]interface{}{
+		"name":  "John",
+		"email": "john@example.com",
+		"role":  "user",
+	}
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_, _ = vd.ValidateMap(value, schema)
+	}
+}
@@ -3350,7 +3350,7 @@ func TestErrorsByField(t *testing.T) {
 		{"CustomField", "An error occurred"},
 	}
 
-	err = Error{"CustomField", fmt.Errorf("An error occurred"), false, "hello", []string{}}
+	err = Error{Name: "CustomField", Err: fmt.Errorf("An error occurred"), Validator: "hello", Path: []string{}}
 	errs = ErrorsByField(err)
 
 	if len(errs) != 1 {